@@ -22,6 +22,6 @@ func main() {
 	cmd.Date = Date
 
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,6 +13,16 @@ import (
 	"github.com/quickspin/quickspin-cli/internal/models"
 )
 
+// ErrNotFound is wrapped by errors for requests whose resource does not exist
+var ErrNotFound = errors.New("resource not found")
+
+// ErrUnauthorized is wrapped by errors for requests rejected for missing or
+// expired credentials
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned for requests the user is not allowed to make
+var ErrForbidden = errors.New("you don't have permission to perform this action")
+
 // Client represents the API client
 type Client struct {
 	httpClient      *resty.Client
@@ -177,13 +188,13 @@ func (c *Client) getUserFriendlyError(apiErr *models.APIError) error {
 	switch apiErr.StatusCode {
 	case http.StatusUnauthorized:
 		if apiErr.Message != "" {
-			return fmt.Errorf("unauthorized: %s", apiErr.Message)
+			return fmt.Errorf("%w: %s", ErrUnauthorized, apiErr.Message)
 		}
-		return fmt.Errorf("unauthorized. Please run 'qspin auth login' to authenticate")
+		return fmt.Errorf("%w. Please run 'qspin auth login' to authenticate", ErrUnauthorized)
 	case http.StatusForbidden:
-		return fmt.Errorf("you don't have permission to perform this action")
+		return ErrForbidden
 	case http.StatusNotFound:
-		return fmt.Errorf("resource not found: %s", apiErr.Message)
+		return fmt.Errorf("%w: %s", ErrNotFound, apiErr.Message)
 	case http.StatusTooManyRequests:
 		return fmt.Errorf("rate limit exceeded. Please try again later")
 	case http.StatusBadRequest:
@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -239,18 +241,21 @@ func TestClientErrorHandling(t *testing.T) {
 		statusCode     int
 		responseBody   string
 		expectedErrMsg string
+		expectedErr    error
 	}{
 		{
 			name:           "Unauthorized",
 			statusCode:     http.StatusUnauthorized,
 			responseBody:   `{"error":"Unauthorized","message":"Invalid token"}`,
 			expectedErrMsg: "unauthorized",
+			expectedErr:    ErrUnauthorized,
 		},
 		{
 			name:           "Forbidden",
 			statusCode:     http.StatusForbidden,
 			responseBody:   `{"error":"Forbidden"}`,
 			expectedErrMsg: "permission",
+			expectedErr:    ErrForbidden,
 		},
 		{
 			name:           "Not Found",
@@ -291,6 +296,9 @@ func TestClientErrorHandling(t *testing.T) {
 
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectedErrMsg)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
 		})
 	}
 }
@@ -378,3 +386,223 @@ func TestClientConcurrentRequests(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestClientStreamServiceLogs(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/services/svc-1/logs/stream", r.URL.Path)
+		assert.Equal(t, "error", r.URL.Query().Get("level"))
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(": keep-alive\n\n"))
+		w.Write([]byte("event: log\ndata: {\"level\":\"error\",\"message\":\"boom\"}\n\n"))
+		w.Write([]byte("data: {\"level\":\"error\",\"message\":\"again\"}\n\n"))
+	})
+	defer server.Close()
+
+	var messages []string
+	err := client.StreamServiceLogs(context.Background(), "svc-1", LogQuery{Level: "error"}, func(entry models.ServiceLogEntry) {
+		messages = append(messages, entry.Message)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"boom", "again"}, messages)
+}
+
+func TestClientStreamServiceLogsUnsupported(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	err := client.StreamServiceLogs(context.Background(), "svc-1", LogQuery{}, func(models.ServiceLogEntry) {})
+	assert.ErrorIs(t, err, ErrStreamingUnsupported)
+}
+
+func TestClientQueryServiceLogs(t *testing.T) {
+	since := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/services/svc-1/logs", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("lines"))
+		assert.Equal(t, "2026-01-01T12:00:00Z", r.URL.Query().Get("since"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"level":"info","message":"ready"}]`))
+	})
+	defer server.Close()
+
+	logs, err := client.QueryServiceLogs(context.Background(), "svc-1", LogQuery{Lines: 50, Since: since})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "ready", logs[0].Message)
+}
+
+func TestClientListServicesWithOptions(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/services", r.URL.Path)
+		assert.Equal(t, "redis,postgresql", r.URL.Query().Get("type"))
+		assert.Equal(t, "running", r.URL.Query().Get("status"))
+		assert.Equal(t, "team=backend", r.URL.Query().Get("label_selector"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":"svc-1","name":"cache"}]`))
+	})
+	defer server.Close()
+
+	services, err := client.ListServicesWithOptions(context.Background(), ServiceListOptions{
+		Types:         []string{"redis", "postgresql"},
+		Statuses:      []string{"running"},
+		LabelSelector: "team=backend",
+	})
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "cache", services[0].Name)
+}
+
+func TestClientServiceLifecycle(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		action func(c *Client) (*models.Service, error)
+	}{
+		{"Stop", "/api/v1/services/svc-1/stop", func(c *Client) (*models.Service, error) { return c.StopService(context.Background(), "svc-1") }},
+		{"Start", "/api/v1/services/svc-1/start", func(c *Client) (*models.Service, error) { return c.StartService(context.Background(), "svc-1") }},
+		{"Restart", "/api/v1/services/svc-1/restart", func(c *Client) (*models.Service, error) { return c.RestartService(context.Background(), "svc-1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, "POST", r.Method)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"id":"svc-1","name":"cache"}`))
+			})
+			defer server.Close()
+
+			svc, err := tt.action(client)
+			require.NoError(t, err)
+			assert.Equal(t, "cache", svc.Name)
+		})
+	}
+}
+
+func TestClientListServiceTypes(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/services/types", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"type":"redis","display_name":"Redis","tiers":[{"tier":"starter","cpu":"0.25","memory":"256Mi","storage":"1Gi","price_monthly":5}]}]`))
+	})
+	defer server.Close()
+
+	types, err := client.ListServiceTypes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, types, 1)
+	assert.Equal(t, models.ServiceTypeRedis, types[0].Type)
+	require.Len(t, types[0].Tiers, 1)
+	assert.Equal(t, 5.0, types[0].Tiers[0].Price)
+}
+
+func TestClientScaleService(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/services/svc-1/scale", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"replicas": float64(3)}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"svc-1","name":"cache","replicas":3}`))
+	})
+	defer server.Close()
+
+	replicas := 3
+	svc, err := client.ScaleService(context.Background(), "svc-1", models.ServiceScaleRequest{Replicas: &replicas})
+	require.NoError(t, err)
+	assert.Equal(t, 3, svc.Replicas)
+}
+
+func TestClientDeployConfig(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/deploy", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "my-company", body["organization"])
+		services := body["services"].([]interface{})
+		require.Len(t, services, 1)
+		assert.Equal(t, map[string]interface{}{"name": "cache", "type": "redis", "tier": "developer"}, services[0])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"dep-1","success":true,"services_created":["cache"]}`))
+	})
+	defer server.Close()
+
+	result, err := client.DeployConfig(context.Background(), models.DeploymentConfig{
+		Version:      "1",
+		Organization: "my-company",
+		Services:     []models.ServiceTemplate{{Name: "cache", Type: models.ServiceTypeRedis, Tier: models.ServiceTierDeveloper}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dep-1", result.ID)
+	assert.Equal(t, []string{"cache"}, result.ServicesCreated)
+}
+
+func TestClientExportConfig(t *testing.T) {
+	status := http.StatusOK
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/organizations/org-1/export", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"version":"1","organization":"org-1","services":[{"name":"cache","type":"redis","tier":"developer"}]}`))
+		} else {
+			w.Write([]byte(`{"error":"not_found","message":"no such route"}`))
+		}
+	})
+	defer server.Close()
+
+	cfg, err := client.ExportConfig(context.Background(), "org-1")
+	require.NoError(t, err)
+	require.Len(t, cfg.Services, 1)
+	assert.Equal(t, "cache", cfg.Services[0].Name)
+
+	for _, code := range []int{http.StatusNotFound, http.StatusMethodNotAllowed} {
+		status = code
+		_, err = client.ExportConfig(context.Background(), "org-1")
+		assert.ErrorIs(t, err, ErrExportUnsupported, code)
+	}
+
+	status = http.StatusForbidden
+	_, err = client.ExportConfig(context.Background(), "org-1")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrExportUnsupported)
+}
+
+func TestClientListDeploymentsWithOptions(t *testing.T) {
+	client, server := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/organizations/org-1/deployments", r.URL.Path)
+		assert.Equal(t, "2026-10-01T00:00:00Z", r.URL.Query().Get("since"))
+		assert.Empty(t, r.URL.Query().Get("until"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"dep-1","success":true,"created_by":"ana@example.com","config":{"version":"1","services":[{"name":"cache","type":"redis"}]}}]`))
+	})
+	defer server.Close()
+
+	deployments, err := client.ListDeploymentsWithOptions(context.Background(), "org-1", DeploymentListOptions{
+		Since: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "ana@example.com", deployments[0].CreatedBy)
+	require.NotNil(t, deployments[0].Config)
+	assert.Equal(t, "cache", deployments[0].Config.Services[0].Name)
+}
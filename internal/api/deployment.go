@@ -2,7 +2,11 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/models"
 )
@@ -37,8 +41,37 @@ func (c *Client) GetDeploymentStatus(ctx context.Context, deploymentID string) (
 
 // ListDeployments retrieves all deployments for an organization
 func (c *Client) ListDeployments(ctx context.Context, orgID string) ([]models.DeploymentResult, error) {
+	return c.ListDeploymentsWithOptions(ctx, orgID, DeploymentListOptions{})
+}
+
+// DeploymentListOptions holds the server-side filters for listing
+// deployments. Servers that don't support a filter ignore it, so callers
+// should still filter the result locally.
+type DeploymentListOptions struct {
+	Since time.Time
+	Until time.Time
+}
+
+// values encodes the options as URL query parameters
+func (o DeploymentListOptions) values() url.Values {
+	v := url.Values{}
+	if !o.Since.IsZero() {
+		v.Set("since", o.Since.UTC().Format(time.RFC3339))
+	}
+	if !o.Until.IsZero() {
+		v.Set("until", o.Until.UTC().Format(time.RFC3339))
+	}
+	return v
+}
+
+// ListDeploymentsWithOptions retrieves the deployments of an organization
+// matching the given filters
+func (c *Client) ListDeploymentsWithOptions(ctx context.Context, orgID string, opts DeploymentListOptions) ([]models.DeploymentResult, error) {
 	var result []models.DeploymentResult
 	path := fmt.Sprintf("/api/v1/organizations/%s/deployments", orgID)
+	if params := opts.values().Encode(); params != "" {
+		path += "?" + params
+	}
 	if err := c.Get(ctx, path, &result); err != nil {
 		return nil, err
 	}
@@ -55,11 +88,19 @@ func (c *Client) RollbackDeployment(ctx context.Context, deploymentID string) (*
 	return &result, nil
 }
 
-// ExportConfig exports current services as deployment config
+// ErrExportUnsupported is returned when the API does not offer the export endpoint
+var ErrExportUnsupported = errors.New("export is not supported by the API")
+
+// ExportConfig exports current services as deployment config. It returns
+// ErrExportUnsupported if the endpoint is unavailable.
 func (c *Client) ExportConfig(ctx context.Context, orgID string) (*models.DeploymentConfig, error) {
 	var result models.DeploymentConfig
 	path := fmt.Sprintf("/api/v1/organizations/%s/export", orgID)
 	if err := c.Get(ctx, path, &result); err != nil {
+		var apiErr *models.APIError
+		if errors.Is(err, ErrNotFound) || (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed) {
+			return nil, fmt.Errorf("%w: %s", ErrExportUnsupported, err)
+		}
 		return nil, err
 	}
 	return &result, nil
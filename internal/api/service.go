@@ -1,16 +1,65 @@
 package api
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/models"
 )
 
 // ListServices retrieves all services for the current user/organization
 func (c *Client) ListServices(ctx context.Context) ([]models.Service, error) {
+	return c.ListServicesWithOptions(ctx, ServiceListOptions{})
+}
+
+// ServiceListOptions holds the server-side filters for listing services.
+// Servers that don't support a filter ignore it, so callers should still
+// filter the result locally.
+type ServiceListOptions struct {
+	Types         []string
+	Statuses      []string
+	Regions       []string
+	Tiers         []string
+	LabelSelector string
+}
+
+// values encodes the options as URL query parameters
+func (o ServiceListOptions) values() url.Values {
+	v := url.Values{}
+	if len(o.Types) > 0 {
+		v.Set("type", strings.Join(o.Types, ","))
+	}
+	if len(o.Statuses) > 0 {
+		v.Set("status", strings.Join(o.Statuses, ","))
+	}
+	if len(o.Regions) > 0 {
+		v.Set("region", strings.Join(o.Regions, ","))
+	}
+	if len(o.Tiers) > 0 {
+		v.Set("tier", strings.Join(o.Tiers, ","))
+	}
+	if o.LabelSelector != "" {
+		v.Set("label_selector", o.LabelSelector)
+	}
+	return v
+}
+
+// ListServicesWithOptions retrieves services matching the given filters
+func (c *Client) ListServicesWithOptions(ctx context.Context, opts ServiceListOptions) ([]models.Service, error) {
 	var result []models.Service
-	if err := c.Get(ctx, "/api/v1/services", &result); err != nil {
+	path := "/api/v1/services"
+	if params := opts.values().Encode(); params != "" {
+		path += "?" + params
+	}
+	if err := c.Get(ctx, path, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -28,12 +77,13 @@ func (c *Client) GetService(ctx context.Context, serviceID string) (*models.Serv
 
 // CreateServiceRequest represents the request to create a new service
 type CreateServiceRequest struct {
-	Name        string              `json:"name"`
-	Type        models.ServiceType  `json:"type"`
-	Tier        models.ServiceTier  `json:"tier"`
-	Region      string              `json:"region,omitempty"`
-	Description string              `json:"description,omitempty"`
+	Name        string                 `json:"name"`
+	Type        models.ServiceType     `json:"type"`
+	Tier        models.ServiceTier     `json:"tier"`
+	Region      string                 `json:"region,omitempty"`
+	Description string                 `json:"description,omitempty"`
 	Config      map[string]interface{} `json:"config,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
 }
 
 // CreateService creates a new service
@@ -45,11 +95,14 @@ func (c *Client) CreateService(ctx context.Context, req CreateServiceRequest) (*
 	return &result, nil
 }
 
-// UpdateServiceRequest represents the request to update a service
+// UpdateServiceRequest represents the request to update a service.
+// Config and Labels are merged into the existing values; a nil value
+// removes the key.
 type UpdateServiceRequest struct {
-	Name        *string             `json:"name,omitempty"`
-	Description *string             `json:"description,omitempty"`
+	Name        *string                `json:"name,omitempty"`
+	Description *string                `json:"description,omitempty"`
 	Config      map[string]interface{} `json:"config,omitempty"`
+	Labels      map[string]*string     `json:"labels,omitempty"`
 }
 
 // UpdateService updates an existing service
@@ -68,14 +121,10 @@ func (c *Client) DeleteService(ctx context.Context, serviceID string) error {
 	return c.Delete(ctx, path, nil)
 }
 
-// ScaleService scales a service to a different tier
-func (c *Client) ScaleService(ctx context.Context, serviceID string, tier models.ServiceTier) (*models.Service, error) {
+// ScaleService changes the tier and/or replica count of a service. Fields
+// left nil in the request are not changed.
+func (c *Client) ScaleService(ctx context.Context, serviceID string, req models.ServiceScaleRequest) (*models.Service, error) {
 	var result models.Service
-	req := struct {
-		Tier models.ServiceTier `json:"tier"`
-	}{
-		Tier: tier,
-	}
 	path := fmt.Sprintf("/api/v1/services/%s/scale", serviceID)
 	if err := c.Post(ctx, path, req, &result); err != nil {
 		return nil, err
@@ -83,16 +132,163 @@ func (c *Client) ScaleService(ctx context.Context, serviceID string, tier models
 	return &result, nil
 }
 
+// StopService stops a running service, keeping its data
+func (c *Client) StopService(ctx context.Context, serviceID string) (*models.Service, error) {
+	return c.serviceAction(ctx, serviceID, "stop")
+}
+
+// StartService starts a stopped service
+func (c *Client) StartService(ctx context.Context, serviceID string) (*models.Service, error) {
+	return c.serviceAction(ctx, serviceID, "start")
+}
+
+// RestartService restarts a service
+func (c *Client) RestartService(ctx context.Context, serviceID string) (*models.Service, error) {
+	return c.serviceAction(ctx, serviceID, "restart")
+}
+
+// serviceAction performs a lifecycle action on a service
+func (c *Client) serviceAction(ctx context.Context, serviceID, action string) (*models.Service, error) {
+	var result models.Service
+	path := fmt.Sprintf("/api/v1/services/%s/%s", serviceID, action)
+	if err := c.Post(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GetServiceLogs retrieves logs for a service
 func (c *Client) GetServiceLogs(ctx context.Context, serviceID string, lines int) ([]models.ServiceLogEntry, error) {
+	return c.QueryServiceLogs(ctx, serviceID, LogQuery{Lines: lines})
+}
+
+// LogQuery holds the optional filters for a service log request
+type LogQuery struct {
+	Lines int
+	Since time.Time
+	Level string
+}
+
+// values encodes the query as URL query parameters
+func (q LogQuery) values() url.Values {
+	v := url.Values{}
+	if q.Lines > 0 {
+		v.Set("lines", strconv.Itoa(q.Lines))
+	}
+	if !q.Since.IsZero() {
+		v.Set("since", q.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if q.Level != "" {
+		v.Set("level", q.Level)
+	}
+	return v
+}
+
+// QueryServiceLogs retrieves logs for a service matching the given query
+func (c *Client) QueryServiceLogs(ctx context.Context, serviceID string, q LogQuery) ([]models.ServiceLogEntry, error) {
 	var result []models.ServiceLogEntry
-	path := fmt.Sprintf("/api/v1/services/%s/logs?lines=%d", serviceID, lines)
+	path := fmt.Sprintf("/api/v1/services/%s/logs", serviceID)
+	if params := q.values().Encode(); params != "" {
+		path += "?" + params
+	}
 	if err := c.Get(ctx, path, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// ErrStreamingUnsupported is returned when the API does not offer a log stream
+var ErrStreamingUnsupported = errors.New("log streaming is not supported by the API")
+
+// StreamServiceLogs follows a service's logs over server-sent events, calling fn
+// for every entry received. It blocks until the context is cancelled or the
+// stream ends, and returns ErrStreamingUnsupported if the endpoint is unavailable.
+func (c *Client) StreamServiceLogs(ctx context.Context, serviceID string, q LogQuery, fn func(models.ServiceLogEntry)) error {
+	path := fmt.Sprintf("/api/v1/services/%s/logs/stream", serviceID)
+	if params := q.values().Encode(); params != "" {
+		path += "?" + params
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.httpClient.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.httpClient.Token)
+	}
+
+	// Streams are long-lived, so they bypass the client-wide request timeout
+	streamClient := &http.Client{Transport: c.httpClient.GetClient().Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusNotImplemented:
+		return ErrStreamingUnsupported
+	}
+	if resp.StatusCode >= 400 {
+		return c.getUserFriendlyError(&models.APIError{
+			StatusCode: resp.StatusCode,
+			Message:    http.StatusText(resp.StatusCode),
+		})
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return ErrStreamingUnsupported
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line terminates an event
+		if line == "" {
+			if data.Len() > 0 {
+				var entry models.ServiceLogEntry
+				if err := json.Unmarshal([]byte(data.String()), &entry); err == nil {
+					fn(entry)
+				}
+				data.Reset()
+			}
+			continue
+		}
+
+		// Only data fields carry log entries; ignore comments, ids and event names
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("log stream interrupted: %w", err)
+	}
+	return nil
+}
+
+// ListServiceTypes retrieves the catalog of service types and their tiers
+func (c *Client) ListServiceTypes(ctx context.Context) ([]models.ServiceTypeInfo, error) {
+	var result []models.ServiceTypeInfo
+	if err := c.Get(ctx, "/api/v1/services/types", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetServiceMetrics retrieves metrics for a service
 func (c *Client) GetServiceMetrics(ctx context.Context, serviceID string) (*models.ServiceMetrics, error) {
 	var result models.ServiceMetrics
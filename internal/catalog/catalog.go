@@ -0,0 +1,196 @@
+// Package catalog caches the server's catalog of service types and tiers so
+// validation, completion and cost previews work without a network round trip.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// DefaultTTL is how long a cached catalog is considered fresh
+const DefaultTTL = 24 * time.Hour
+
+// Catalog is a snapshot of the available service types and tiers
+type Catalog struct {
+	FetchedAt time.Time                `json:"fetched_at"`
+	Types     []models.ServiceTypeInfo `json:"types"`
+
+	// Stale is set when an expired cache was returned, either because fetching
+	// failed or because it was read without touching the network
+	Stale bool `json:"-"`
+}
+
+// Type returns the info for a service type, or nil if it is not in the catalog
+func (c *Catalog) Type(serviceType models.ServiceType) *models.ServiceTypeInfo {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Types {
+		if c.Types[i].Type == serviceType {
+			return &c.Types[i]
+		}
+	}
+	return nil
+}
+
+// Tier returns the info for a tier of a service type, or nil if it is not in the catalog
+func (c *Catalog) Tier(serviceType models.ServiceType, tier models.ServiceTier) *models.TierInfo {
+	info := c.Type(serviceType)
+	if info == nil {
+		return nil
+	}
+	for i := range info.Tiers {
+		if info.Tiers[i].Tier == tier {
+			return &info.Tiers[i]
+		}
+	}
+	return nil
+}
+
+// ServiceTypes returns the catalog entries, or nil for a nil catalog, so it
+// can be passed straight to models.NewValidator
+func (c *Catalog) ServiceTypes() []models.ServiceTypeInfo {
+	if c == nil {
+		return nil
+	}
+	return c.Types
+}
+
+// Fetcher retrieves the catalog from the API
+type Fetcher interface {
+	ListServiceTypes(ctx context.Context) ([]models.ServiceTypeInfo, error)
+}
+
+// Cache stores the catalog on disk
+type Cache struct {
+	Path string
+	TTL  time.Duration
+
+	now func() time.Time
+}
+
+// GetCachePath returns the default catalog cache location
+func GetCachePath() string {
+	return filepath.Join(config.New().GetConfigDir(), "cache", "catalog.json")
+}
+
+// NewCache creates a cache at the default location with the default TTL
+func NewCache() *Cache {
+	return &Cache{Path: GetCachePath(), TTL: DefaultTTL, now: time.Now}
+}
+
+// Read returns the cached catalog regardless of its age, or nil if there is none
+func (c *Cache) Read() (*Catalog, error) {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read catalog cache: %w", err)
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog cache: %w", err)
+	}
+	return &catalog, nil
+}
+
+// Write stores a catalog in the cache
+func (c *Cache) Write(catalog *Catalog) error {
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode catalog: %w", err)
+	}
+
+	if err := os.WriteFile(c.Path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write catalog cache: %w", err)
+	}
+	return nil
+}
+
+// Fresh reports whether a cached catalog is younger than the TTL
+func (c *Cache) Fresh(catalog *Catalog) bool {
+	return catalog != nil && c.now().Sub(catalog.FetchedAt) < c.TTL
+}
+
+// Load returns the cached catalog if it is fresh, and otherwise fetches and
+// caches a new one. If fetching fails an expired cache is returned with Stale
+// set. refresh skips the cache.
+func (c *Cache) Load(ctx context.Context, fetcher Fetcher, refresh bool) (*Catalog, error) {
+	// An unreadable cache is treated as missing
+	cached, _ := c.Read()
+	if !refresh && c.Fresh(cached) {
+		return cached, nil
+	}
+
+	types, err := fetcher.ListServiceTypes(ctx)
+	if err != nil {
+		if cached != nil {
+			cached.Stale = true
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	catalog := &Catalog{FetchedAt: c.now().UTC(), Types: types}
+	if err := c.Write(catalog); err != nil {
+		return catalog, err
+	}
+	return catalog, nil
+}
+
+// Cached returns the cached catalog without touching the network, or nil if
+// there is none or it cannot be read. Stale is set once it is older than the
+// TTL.
+func (c *Cache) Cached() *Catalog {
+	catalog, err := c.Read()
+	if err != nil || catalog == nil {
+		return nil
+	}
+	catalog.Stale = !c.Fresh(catalog)
+	return catalog
+}
+
+// Cached returns the catalog from the default cache without touching the
+// network, for commands that work offline
+func Cached() *Catalog {
+	return NewCache().Cached()
+}
+
+// Current returns the catalog from the default cache, fetching a new one when
+// it has expired. If fetching fails the expired catalog is returned with Stale
+// set, or nil when there is none so callers fall back to the built-in lists.
+func Current(ctx context.Context, fetcher Fetcher) *Catalog {
+	// A fetched catalog that could not be cached is still current
+	catalog, _ := NewCache().Load(ctx, fetcher, false)
+	return catalog
+}
+
+// Unverified reports whether err is an unknown type or tier error from a
+// stale catalog. The type or tier may have been added since the catalog was
+// cached, so callers report these as warnings rather than failing.
+func (c *Catalog) Unverified(err error) bool {
+	if c == nil || !c.Stale {
+		return false
+	}
+	var verr *models.ValidationError
+	return errors.As(err, &verr) && (verr.Field == "service type" || verr.Field == "tier")
+}
+
+// UnverifiedWarning is the message to show for an error Unverified reports
+func UnverifiedWarning(err error) string {
+	return fmt.Sprintf("%s (the cached service catalog is out of date, run 'qspin catalog types --refresh')", err)
+}
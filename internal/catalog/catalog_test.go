@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	types []models.ServiceTypeInfo
+	err   error
+	calls int
+}
+
+func (f *fakeFetcher) ListServiceTypes(ctx context.Context) ([]models.ServiceTypeInfo, error) {
+	f.calls++
+	return f.types, f.err
+}
+
+func newTestCache(t *testing.T, now *time.Time) *Cache {
+	return &Cache{
+		Path: filepath.Join(t.TempDir(), "cache", "catalog.json"),
+		TTL:  time.Hour,
+		now:  func() time.Time { return *now },
+	}
+}
+
+func TestCacheLoad(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newTestCache(t, &now)
+	fetcher := &fakeFetcher{types: []models.ServiceTypeInfo{
+		{Type: models.ServiceTypeRedis, Tiers: []models.TierInfo{{Tier: models.ServiceTierStarter, Price: 5}}},
+	}}
+
+	// Empty cache fetches
+	catalog, err := cache.Load(context.Background(), fetcher, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetcher.calls)
+	assert.Equal(t, 5.0, catalog.Tier(models.ServiceTypeRedis, models.ServiceTierStarter).Price)
+
+	// Fresh cache is reused
+	now = now.Add(30 * time.Minute)
+	_, err = cache.Load(context.Background(), fetcher, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetcher.calls)
+
+	// Refresh skips the cache
+	_, err = cache.Load(context.Background(), fetcher, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetcher.calls)
+
+	// Expired cache is returned as stale when fetching fails
+	now = now.Add(2 * time.Hour)
+	fetcher.err = errors.New("offline")
+	catalog, err = cache.Load(context.Background(), fetcher, false)
+	require.NoError(t, err)
+	assert.True(t, catalog.Stale)
+	assert.NotNil(t, catalog.Type(models.ServiceTypeRedis))
+}
+
+func TestCacheLoadWithoutCache(t *testing.T) {
+	now := time.Now()
+	cache := newTestCache(t, &now)
+
+	_, err := cache.Load(context.Background(), &fakeFetcher{err: errors.New("offline")}, false)
+	assert.Error(t, err)
+
+	catalog, err := cache.Read()
+	assert.NoError(t, err)
+	assert.Nil(t, catalog)
+}
+
+func TestCatalogLookups(t *testing.T) {
+	var nilCatalog *Catalog
+	assert.Nil(t, nilCatalog.Type(models.ServiceTypeRedis))
+	assert.Nil(t, nilCatalog.ServiceTypes())
+
+	catalog := &Catalog{Types: []models.ServiceTypeInfo{{Type: models.ServiceTypeMySQL}}}
+	assert.NotNil(t, catalog.Type(models.ServiceTypeMySQL))
+	assert.Nil(t, catalog.Tier(models.ServiceTypeMySQL, models.ServiceTierPro))
+	assert.Nil(t, catalog.Type(models.ServiceTypeRedis))
+}
+
+func TestCacheCached(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newTestCache(t, &now)
+	assert.Nil(t, cache.Cached())
+
+	require.NoError(t, cache.Write(&Catalog{FetchedAt: now, Types: []models.ServiceTypeInfo{{Type: models.ServiceTypeRedis}}}))
+	catalog := cache.Cached()
+	require.NotNil(t, catalog)
+	assert.False(t, catalog.Stale)
+
+	// An expired cache is still returned, marked stale
+	now = now.Add(2 * time.Hour)
+	catalog = cache.Cached()
+	require.NotNil(t, catalog)
+	assert.True(t, catalog.Stale)
+}
+
+func TestCatalogUnverified(t *testing.T) {
+	catalog := &Catalog{Types: []models.ServiceTypeInfo{{Type: models.ServiceTypeRedis}}}
+	validator := models.NewValidator(catalog.Types)
+	typeErr := validator.ValidateType(models.ServiceTypeMySQL)
+	tierErr := validator.ValidateTier(models.ServiceTypeRedis, "huge")
+	nameErr := models.ValidateServiceName("Bad Name")
+	require.Error(t, typeErr)
+	require.Error(t, tierErr)
+	require.Error(t, nameErr)
+
+	// A fresh catalog is trusted
+	assert.False(t, catalog.Unverified(typeErr))
+
+	catalog.Stale = true
+	assert.True(t, catalog.Unverified(typeErr))
+	assert.True(t, catalog.Unverified(fmt.Errorf("services.api: %w", tierErr)))
+	assert.False(t, catalog.Unverified(nameErr))
+	assert.False(t, catalog.Unverified(nil))
+
+	var nilCatalog *Catalog
+	assert.False(t, nilCatalog.Unverified(typeErr))
+}
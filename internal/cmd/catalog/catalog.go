@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	catalogpkg "github.com/quickspin/quickspin-cli/internal/catalog"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var refresh bool
+
+// NewCatalogCmd creates the catalog command
+func NewCatalogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Browse available service types and tiers",
+		Long: `Browse the service types and tiers offered by QuickSpin, with their
+resources and monthly prices.
+
+The catalog is cached in ~/.quickspin/cache for a day so validation, shell
+completion and cost previews work offline. Use --refresh to fetch it again.`,
+	}
+
+	cmd.PersistentFlags().BoolVar(&refresh, "refresh", false, "Ignore the cached catalog and fetch it from the API")
+
+	// Add subcommands
+	cmd.AddCommand(NewTypesCmd())
+	cmd.AddCommand(NewTiersCmd())
+
+	return cmd
+}
+
+// loadCatalog returns the cached catalog, fetching it when it has expired
+func loadCatalog(ctx context.Context) (*catalogpkg.Catalog, error) {
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	cache := catalogpkg.NewCache()
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Loading catalog...")
+	spinner.Start()
+
+	catalog, err := cache.Load(ctx, client, refresh)
+	spinner.Stop()
+
+	if catalog == nil {
+		outputpkg.Error(fmt.Sprintf("Failed to load catalog: %s", err))
+		return nil, err
+	}
+	if err != nil {
+		outputpkg.Warning(fmt.Sprintf("Failed to cache catalog: %s", err))
+	}
+	if catalog.Stale {
+		outputpkg.Warning(fmt.Sprintf("Could not reach the API, using catalog cached at %s",
+			catalog.FetchedAt.Local().Format("2006-01-02 15:04")))
+	}
+
+	return catalog, nil
+}
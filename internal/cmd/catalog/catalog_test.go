@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTypes = []models.ServiceTypeInfo{
+	{
+		Type:        models.ServiceTypeRedis,
+		DisplayName: "Redis",
+		Tiers: []models.TierInfo{
+			{Tier: models.ServiceTierPro, CPU: "2", Memory: "4Gi", Storage: "20Gi", Price: 80},
+			{Tier: models.ServiceTierStarter, CPU: "0.25", Memory: "256Mi", Storage: "1Gi", Price: 5},
+		},
+	},
+	{
+		Type:        models.ServiceTypePostgreSQL,
+		DisplayName: "PostgreSQL",
+		Tiers:       []models.TierInfo{{Tier: models.ServiceTierDeveloper, Price: 15}},
+	},
+}
+
+func TestCatalogCommand(t *testing.T) {
+	cmd := NewCatalogCmd()
+	require.NotNil(t, cmd)
+	assert.Equal(t, "catalog", cmd.Use)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("refresh"))
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"types", "tiers"}, names)
+
+	tiers, _, err := cmd.Find([]string{"tiers"})
+	require.NoError(t, err)
+	assert.NotNil(t, tiers.Flags().Lookup("type"))
+}
+
+func TestTypeRows(t *testing.T) {
+	rows := typeRows(testTypes)
+	require.Len(t, rows, 2)
+	assert.Equal(t, typeRow{Type: models.ServiceTypeRedis, Name: "Redis", Tiers: 2}, rows[0])
+}
+
+func TestTierRows(t *testing.T) {
+	rows := tierRows(testTypes)
+	require.Len(t, rows, 3)
+
+	// Tiers are ordered from smallest to largest within a type
+	assert.Equal(t, models.ServiceTierStarter, rows[0].Tier)
+	assert.Equal(t, "$5.00/mo", rows[0].Price)
+	assert.Equal(t, models.ServiceTierPro, rows[1].Tier)
+	assert.Equal(t, models.ServiceTypePostgreSQL, rows[2].Type)
+}
+
+func TestSelectTypes(t *testing.T) {
+	all, err := selectTypes(testTypes, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	redis, err := selectTypes(testTypes, "redis")
+	require.NoError(t, err)
+	require.Len(t, redis, 1)
+	assert.Equal(t, models.ServiceTypeRedis, redis[0].Type)
+
+	_, err = selectTypes(testTypes, "postgres")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean postgresql?")
+}
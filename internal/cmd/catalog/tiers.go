@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var tiersType string
+
+// NewTiersCmd creates the catalog tiers command
+func NewTiersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tiers",
+		Short: "List tiers with resources and prices",
+		Example: `  qspin catalog tiers
+  qspin catalog tiers --type redis`,
+		Args: cobra.NoArgs,
+		RunE: runTiers,
+	}
+
+	cmd.Flags().StringVar(&tiersType, "type", "", "Only show tiers for this service type")
+
+	return cmd
+}
+
+// tierRow is a table row for a tier of a service type
+type tierRow struct {
+	Type    models.ServiceType
+	Tier    models.ServiceTier
+	CPU     string
+	Memory  string
+	Storage string
+	Price   string
+}
+
+// tierRows flattens the tiers of the given types, ordered by type and then from
+// the smallest tier to the largest
+func tierRows(types []models.ServiceTypeInfo) []tierRow {
+	var rows []tierRow
+	for _, info := range types {
+		tiers := append([]models.TierInfo(nil), info.Tiers...)
+		sort.SliceStable(tiers, func(i, j int) bool {
+			return tiers[i].Tier.Rank() < tiers[j].Tier.Rank()
+		})
+		for _, tier := range tiers {
+			rows = append(rows, tierRow{
+				Type:    info.Type,
+				Tier:    tier.Tier,
+				CPU:     tier.CPU,
+				Memory:  tier.Memory,
+				Storage: tier.Storage,
+				Price:   fmt.Sprintf("$%.2f/mo", tier.Price),
+			})
+		}
+	}
+	return rows
+}
+
+// selectTypes returns the catalog entries for a type, or all entries when serviceType is empty
+func selectTypes(types []models.ServiceTypeInfo, serviceType string) ([]models.ServiceTypeInfo, error) {
+	if serviceType == "" {
+		return types, nil
+	}
+	for _, info := range types {
+		if string(info.Type) == serviceType {
+			return []models.ServiceTypeInfo{info}, nil
+		}
+	}
+	return nil, models.NewValidator(types).ValidateType(models.ServiceType(serviceType))
+}
+
+func runTiers(cmd *cobra.Command, args []string) error {
+	catalog, err := loadCatalog(context.Background())
+	if err != nil {
+		return err
+	}
+
+	types, err := selectTypes(catalog.Types, tiersType)
+	if err != nil {
+		return err
+	}
+
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	if formatType == outputpkg.FormatJSON || formatType == outputpkg.FormatYAML {
+		return outputpkg.Print(formatType, types)
+	}
+
+	rows := tierRows(types)
+	if len(rows) == 0 {
+		outputpkg.Info("No tiers available")
+		return nil
+	}
+
+	return outputpkg.PrintList(outputpkg.FormatTable, rows, []string{"TYPE", "TIER", "CPU", "MEMORY", "STORAGE", "PRICE"})
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewTypesCmd creates the catalog types command
+func NewTypesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "types",
+		Short: "List available service types",
+		Example: `  qspin catalog types
+  qspin catalog types -o json`,
+		Args: cobra.NoArgs,
+		RunE: runTypes,
+	}
+}
+
+// typeRow is a table row for a service type
+type typeRow struct {
+	Type        models.ServiceType
+	Name        string
+	Tiers       int
+	Description string
+}
+
+// typeRows converts catalog entries into table rows
+func typeRows(types []models.ServiceTypeInfo) []typeRow {
+	rows := make([]typeRow, 0, len(types))
+	for _, info := range types {
+		rows = append(rows, typeRow{
+			Type:        info.Type,
+			Name:        info.DisplayName,
+			Tiers:       len(info.Tiers),
+			Description: info.Description,
+		})
+	}
+	return rows
+}
+
+func runTypes(cmd *cobra.Command, args []string) error {
+	catalog, err := loadCatalog(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(catalog.Types) == 0 {
+		outputpkg.Info("No service types available")
+		return nil
+	}
+
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	if formatType != outputpkg.FormatJSON && formatType != outputpkg.FormatYAML {
+		outputpkg.Success(fmt.Sprintf("Found %d service type(s)", len(catalog.Types)))
+		fmt.Println()
+		return outputpkg.PrintList(outputpkg.FormatTable, typeRows(catalog.Types), []string{"TYPE", "NAME", "TIERS", "DESCRIPTION"})
+	}
+	return outputpkg.Print(formatType, catalog.Types)
+}
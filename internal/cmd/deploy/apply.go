@@ -0,0 +1,225 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyFiles  fileFlags
+	applyDryRun bool
+	applyPrune  bool
+	applyYes    bool
+
+	applyAllowExec bool
+
+	applyParallelism int
+	applyTimeout     time.Duration
+)
+
+// NewApplyCmd creates the deploy apply command
+func NewApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Deploy the services in a file",
+		Long: `Create or update the services declared in a deployment file.
+
+The file is validated locally and compared with the live services, and the
+resulting plan is shown before anything changes. With --dry-run the command
+stops after the plan.
+
+Every applied service is labeled quickspin.io/managed-by=qspin-deploy. With
+--prune, live services carrying that label that are no longer in the file are
+deleted after the deployment succeeds. Services created by other means and
+preview copies are never pruned.
+
+When services declare depends_on, or --parallelism is set, the CLI applies
+the services itself: independent services are created or updated
+concurrently, and each service starts only once its dependencies are
+running. Progress is shown per service.
+
+Config values may reference secrets kept out of the file with ${file:path},
+${env:NAME} or ${exec:command}. They are resolved only when applying, with
+relative paths read from the deployment file's directory, and plans show
+them redacted. ${exec:command} runs the command with sh -c as the current
+user, so anyone who can edit the file can run commands on the machine
+applying it. It is refused unless --allow-exec is given.
+
+When the deployment file is in a git working tree, the services created or
+updated are labeled with the commit, branch and origin they were deployed
+from (quickspin.io/git-commit, quickspin.io/git-branch and
+quickspin.io/git-repo), plus quickspin.io/dirty=true if the tree has
+uncommitted changes.
+
+The command exits non-zero if any service fails to deploy.`,
+		Example: `  qspin deploy apply -f quickspin.yaml
+  qspin deploy apply -f quickspin.yaml --dry-run
+  qspin deploy apply -f quickspin.yaml --prune --yes
+  qspin deploy apply -f quickspin.yaml --parallelism 2
+  qspin deploy apply -f quickspin.yaml --allow-exec
+  cat quickspin.yaml | qspin deploy apply -f -`,
+		Args: cobra.NoArgs,
+		RunE: runApply,
+	}
+
+	applyFiles.register(cmd)
+	cmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Print the plan without applying it")
+	cmd.Flags().BoolVar(&applyPrune, "prune", false, "Delete services applied from a deployment file that are no longer in it")
+	cmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "Skip confirmation prompt when pruning")
+	cmd.Flags().IntVar(&applyParallelism, "parallelism", defaultParallelism, "Maximum number of services to apply at once (client-side apply)")
+	cmd.Flags().DurationVar(&applyTimeout, "timeout", 10*time.Minute, "Maximum time to wait for services to be running (client-side apply)")
+	cmd.Flags().BoolVar(&applyAllowExec, "allow-exec", false, "Allow ${exec:command} secrets to run shell commands")
+
+	return cmd
+}
+
+// serviceDeleter is the subset of the API client needed to prune services
+type serviceDeleter interface {
+	DeleteService(ctx context.Context, serviceID string) error
+}
+
+// pruneServices deletes the services a plan marks for deletion, recording the
+// outcome of each in the result
+func pruneServices(ctx context.Context, client serviceDeleter, deletes []deploypkg.ServicePlan, result *models.DeploymentResult) {
+	for _, svc := range deletes {
+		if err := client.DeleteService(ctx, svc.ServiceID); err != nil {
+			result.ServicesFailed = append(result.ServicesFailed, models.DeploymentError{
+				ServiceName: svc.Name,
+				Error:       fmt.Sprintf("delete failed: %s", err),
+			})
+			result.Success = false
+			continue
+		}
+		result.ServicesDeleted = append(result.ServicesDeleted, svc.Name)
+	}
+}
+
+// stampProvenance labels the services a plan creates or updates with the git
+// commit they are deployed from and adds the label changes to the plan, so the
+// plan shown is the one applied. Outside a git working tree the deployment is
+// returned as is.
+func stampProvenance(ctx context.Context, deployment *models.DeploymentConfig, plan *deploypkg.Plan, dir string) *models.DeploymentConfig {
+	provenance, err := deploypkg.ReadProvenance(ctx, dir)
+	if err != nil {
+		outputpkg.Warning(fmt.Sprintf("Could not read git provenance: %s", err))
+		return deployment
+	}
+	if provenance == nil {
+		return deployment
+	}
+	return deploypkg.StampProvenance(deployment, plan, provenance)
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	deployment, err := loadDeployment(ctx, applyFiles, cfg, client)
+	if err != nil {
+		return err
+	}
+	if err := checkExec(deployment, applyAllowExec); err != nil {
+		return err
+	}
+	deployment = deploypkg.Manage(deployment)
+
+	graph, err := deploypkg.BuildGraph(deployment)
+	if err != nil {
+		return err
+	}
+
+	plan, err := buildPlan(ctx, client, deployment, applyPrune)
+	if err != nil {
+		return err
+	}
+	deployment = stampProvenance(ctx, deployment, plan, applyFiles.dir())
+
+	if applyDryRun {
+		format, err := planOutputFormat("")
+		if err != nil {
+			return err
+		}
+		return plan.Render(os.Stdout, format, outputpkg.SupportsColor())
+	}
+
+	plan.RenderText(os.Stdout, outputpkg.SupportsColor())
+	fmt.Println()
+
+	if !plan.HasChanges() {
+		outputpkg.Success("All services are up to date")
+		return nil
+	}
+
+	deletes := plan.Deletes()
+	if len(deletes) > 0 && !applyYes && !outputpkg.Confirm(fmt.Sprintf("This will delete %d service(s) and their data. Continue?", len(deletes))) {
+		outputpkg.Info("Apply cancelled")
+		return nil
+	}
+
+	// Secrets are only read now, after the plan has been shown
+	resolved, err := deploypkg.NewSecretResolver(applyFiles.dir(), applyAllowExec).Resolve(ctx, deployment)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to resolve secrets: %s", err))
+		return err
+	}
+
+	var result *models.DeploymentResult
+	if graph.HasDependencies() || cmd.Flags().Changed("parallelism") {
+		applyCtx, cancel := context.WithTimeout(ctx, applyTimeout)
+		progress := newApplyProgress(os.Stdout, graph.Order(), outputpkg.SupportsColor())
+		result = applyInOrder(applyCtx, client, plan, resolved, graph, applyParallelism, progress)
+		cancel()
+		fmt.Println()
+	} else {
+		// Show spinner
+		spinner := outputpkg.NewSpinner(fmt.Sprintf("Deploying %d service(s)...", len(deployment.Services)))
+		spinner.Start()
+
+		// Deploy
+		result, err = client.DeployConfig(ctx, *resolved)
+		spinner.Stop()
+
+		if err != nil {
+			outputpkg.Error(fmt.Sprintf("Failed to deploy: %s", err))
+			return err
+		}
+	}
+
+	// Only prune once everything in the file is deployed
+	if len(deletes) > 0 {
+		if resultError(result) != nil {
+			outputpkg.Warning("Skipping pruning because the deployment did not complete")
+		} else {
+			spinner := outputpkg.NewSpinner(fmt.Sprintf("Deleting %d service(s)...", len(deletes)))
+			spinner.Start()
+			pruneServices(ctx, client, deletes, result)
+			spinner.Stop()
+		}
+	}
+
+	if resultError(result) == nil {
+		outputpkg.Success(fmt.Sprintf("Deployed %d service(s)", len(result.ServicesCreated)))
+	} else {
+		outputpkg.Error("Deployment did not complete")
+	}
+	fmt.Println()
+
+	return printResult(os.Stdout, result)
+}
@@ -0,0 +1,159 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Files written by deploy compose
+const (
+	composeFileName = "docker-compose.yml"
+	composeEnvName  = ".env"
+)
+
+var (
+	composeFiles fileFlags
+	composeDir   string
+	composeForce bool
+)
+
+// NewComposeCmd creates the deploy compose command
+func NewComposeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Generate a docker-compose file for local development",
+		Long: `Write a docker-compose.yml that runs the services of a deployment file as
+local containers, and a .env file with their connection variables, for
+working offline against the same stack.
+
+Variables have the names 'qspin service connect' uses, such as REDIS_URL
+and DATABASE_URL, so code reads them the same way locally and in the cloud.
+When two services would share a name, every variable is prefixed with its
+service name instead, e.g. CACHE_REDIS_URL.
+
+The config keys maxmemory, maxmemory-policy and persistence (Redis), vhost
+(RabbitMQ), database (PostgreSQL, MongoDB, MySQL) and extensions
+(PostgreSQL) are translated; other keys are listed as ignored. Ports are
+published on localhost only. No API access is needed.`,
+		Example: `  qspin deploy compose -f quickspin.yaml
+  qspin deploy compose -f quickspin.yaml --dir local --force
+  docker compose up -d`,
+		Args: cobra.NoArgs,
+		RunE: runCompose,
+	}
+
+	composeFiles.register(cmd)
+	cmd.Flags().StringVar(&composeDir, "dir", ".", "Directory to write docker-compose.yml and .env to")
+	cmd.Flags().BoolVar(&composeForce, "force", false, "Overwrite existing files")
+
+	return cmd
+}
+
+// composeEnv returns the connection variables of the local services. Services
+// are only prefixed with their name when their variable names would collide.
+func composeEnv(services []deploypkg.ComposeCredentials) []servicepkg.EnvVar {
+	seen := make(map[string]bool)
+	collision := false
+	for _, s := range services {
+		for _, v := range servicepkg.CredentialEnv(s.Type, s.Credentials, "") {
+			if seen[v.Name] {
+				collision = true
+			}
+			seen[v.Name] = true
+		}
+	}
+
+	var vars []servicepkg.EnvVar
+	for _, s := range services {
+		prefix := ""
+		if collision {
+			prefix = s.Name + "_"
+		}
+		vars = append(vars, servicepkg.CredentialEnv(s.Type, s.Credentials, prefix)...)
+	}
+	return vars
+}
+
+// writeComposeEnv writes variables as a .env file
+func writeComposeEnv(w io.Writer, vars []servicepkg.EnvVar) error {
+	var b strings.Builder
+	b.WriteString("# Generated by qspin deploy compose. Local development credentials only.\n")
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%s=%s\n", v.Name, servicepkg.DotenvQuote(v.Value))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func runCompose(cmd *cobra.Command, args []string) error {
+	deployment, err := composeFiles.resolve()
+	if err != nil {
+		return err
+	}
+	if err := checkDeployment(deployment, catalog.Cached(), composeFiles.File); err != nil {
+		return err
+	}
+
+	project, err := deploypkg.Compose(deployment)
+	if err != nil {
+		return err
+	}
+	composeData, err := deploypkg.FormatCompose(project.File)
+	if err != nil {
+		return fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	var env strings.Builder
+	if err := writeComposeEnv(&env, composeEnv(project.Credentials)); err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		composeFileName: string(composeData),
+		composeEnvName:  env.String(),
+	}
+	for path, content := range project.InitFiles {
+		files[filepath.FromSlash(path)] = content
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	if !composeForce {
+		for _, path := range paths {
+			full := filepath.Join(composeDir, path)
+			if _, err := os.Stat(full); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", full)
+			}
+		}
+	}
+
+	for _, path := range paths {
+		full := filepath.Join(composeDir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(full), err)
+		}
+		if err := os.WriteFile(full, []byte(files[path]), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", full, err)
+		}
+	}
+
+	outputpkg.Success(fmt.Sprintf("Wrote %d service(s) to %s and %s",
+		len(deployment.Services), filepath.Join(composeDir, composeFileName), filepath.Join(composeDir, composeEnvName)))
+	if len(project.Ignored) > 0 {
+		outputpkg.Warning(fmt.Sprintf("No local equivalent for %s", strings.Join(project.Ignored, ", ")))
+	}
+	outputpkg.Info("Start the services with: docker compose up -d")
+	return nil
+}
@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewDeployCmd creates the deploy command
+func NewDeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploy services from GitOps files",
+		Long: `Deploy services declared in a quickspin.yaml file and manage past deployments.
+
+See configs/quickspin-services.example.yaml for the file format.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewApplyCmd())
+	cmd.AddCommand(NewValidateCmd())
+	cmd.AddCommand(NewLintCmd())
+	cmd.AddCommand(NewSchemaCmd())
+	cmd.AddCommand(NewPlanCmd())
+	cmd.AddCommand(NewDriftCmd())
+	cmd.AddCommand(NewRenderCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewComposeCmd())
+	cmd.AddCommand(NewStatusCmd())
+	cmd.AddCommand(NewHistoryCmd())
+	cmd.AddCommand(NewShowCmd())
+	cmd.AddCommand(NewDiffCmd())
+	cmd.AddCommand(NewRollbackCmd())
+
+	return cmd
+}
+
+// fileFlags are the flags that select and resolve a deployment file
+type fileFlags struct {
+	File     string
+	EnvFiles []string
+	Overlays []string
+}
+
+// register adds the deployment file flags to a command
+func (f *fileFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.File, "file", "f", "", "Deployment file (- for stdin)")
+	cmd.Flags().StringArrayVar(&f.EnvFiles, "env-file", nil, "File of KEY=VALUE variables for ${VAR} references (repeatable)")
+	cmd.Flags().StringArrayVar(&f.Overlays, "overlay", nil, "Overlay file merged onto the deployment file, e.g. quickspin.prod.yaml (repeatable)")
+}
+
+// resolve reads the deployment file with its env files and overlays applied
+func (f *fileFlags) resolve() (*models.DeploymentConfig, error) {
+	if f.File == "" {
+		return nil, fmt.Errorf("deployment file is required (use -f flag)")
+	}
+	return deploypkg.Load(f.File, deploypkg.LoadOptions{
+		EnvFiles: f.EnvFiles,
+		Overlays: f.Overlays,
+	})
+}
+
+// dir is the directory relative ${file:...} secret paths are read from
+func (f *fileFlags) dir() string {
+	if f.File == "-" {
+		return "."
+	}
+	return filepath.Dir(f.File)
+}
+
+// loadDeployment resolves a deployment file, fills in the organization from
+// the config when the file does not set one, and validates it locally. The
+// catalog is refreshed through fetcher when it has expired, or read from the
+// cache alone when fetcher is nil.
+func loadDeployment(ctx context.Context, files fileFlags, cfg *config.Config, fetcher catalog.Fetcher) (*models.DeploymentConfig, error) {
+	deployment, err := files.resolve()
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Organization == "" {
+		deployment.Organization = cfg.GetDefaultOrganization()
+	}
+
+	cat := catalog.Cached()
+	if fetcher != nil {
+		cat = catalog.Current(ctx, fetcher)
+	}
+	if err := checkDeployment(deployment, cat, files.File); err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// checkExec fails when a deployment reads secrets with ${exec:...} and
+// --allow-exec was not given, before anything is planned or changed
+func checkExec(deployment *models.DeploymentConfig, allowExec bool) error {
+	if refs := deploypkg.ExecRefs(deployment); len(refs) > 0 && !allowExec {
+		return fmt.Errorf("%s runs a shell command; pass --allow-exec to allow ${exec:...} secrets", refs[0])
+	}
+	return nil
+}
+
+// checkDeployment validates a deployment and prints each problem. Unknown
+// types and tiers are only warned about when the catalog is stale.
+func checkDeployment(deployment *models.DeploymentConfig, cat *catalog.Catalog, file string) error {
+	problems := 0
+	for _, err := range deploypkg.Validate(deployment, cat.ServiceTypes()) {
+		if cat.Unverified(err) {
+			outputpkg.Warning(catalog.UnverifiedWarning(err))
+			continue
+		}
+		outputpkg.Error(err.Error())
+		problems++
+	}
+	if problems > 0 {
+		return fmt.Errorf("%s has %d problem(s)", file, problems)
+	}
+	return nil
+}
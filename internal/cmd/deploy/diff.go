@@ -0,0 +1,96 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var diffFormat string
+
+// NewDiffCmd creates the deploy diff command
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff FROM_ID TO_ID",
+		Short: "Compare the configurations of two deployments",
+		Long: `Compare the deployment files applied by two deployments, shown as the changes
+needed to go from the first to the second: services added (+), changed (~)
+and removed (-).
+
+Use it to pick a rollback target: 'qspin deploy diff CURRENT_ID OLDER_ID'
+shows what 'qspin deploy rollback' would change. Credentials are compared
+but not shown.`,
+		Example: `  qspin deploy diff dep_abc123 dep_def456
+  qspin deploy diff dep_abc123 dep_def456 --format markdown`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+
+	cmd.Flags().StringVar(&diffFormat, "format", "", "Output format: text, json or markdown")
+
+	return cmd
+}
+
+// deploymentGetter is the subset of the API client needed to read deployments
+type deploymentGetter interface {
+	GetDeploymentStatus(ctx context.Context, deploymentID string) (*models.DeploymentResult, error)
+}
+
+// deploymentConfig reads the deployment file applied by a deployment
+func deploymentConfig(ctx context.Context, client deploymentGetter, deploymentID string) (*models.DeploymentConfig, error) {
+	deployment, err := client.GetDeploymentStatus(ctx, deploymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s: %w", deploymentID, err)
+	}
+	if deployment.Config == nil {
+		return nil, fmt.Errorf("deployment %s does not include its configuration", deploymentID)
+	}
+	return deployment.Config, nil
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	format, err := planOutputFormat(diffFormat)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Loading deployments...")
+	spinner.Start()
+
+	from, err := deploymentConfig(ctx, client, args[0])
+	var to *models.DeploymentConfig
+	if err == nil {
+		to, err = deploymentConfig(ctx, client, args[1])
+	}
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(err.Error())
+		return err
+	}
+
+	plan := deploypkg.DiffConfigs(from, to)
+	if format == deploypkg.PlanFormatText {
+		fmt.Printf("Changes from %s to %s:\n\n", args[0], args[1])
+	}
+	return plan.Render(os.Stdout, format, outputpkg.SupportsColor())
+}
@@ -0,0 +1,126 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Exit codes of deploy drift
+const (
+	DriftExitInSync = 0
+	DriftExitError  = 1
+	DriftExitDrift  = 2
+)
+
+var (
+	driftFiles     fileFlags
+	driftUnmanaged bool
+)
+
+// ExitError is an error that carries a specific process exit code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit code
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// NewDriftCmd creates the deploy drift command
+func NewDriftCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Detect services that no longer match a deployment file",
+		Long: `Compare a deployment file with the live services and report services that
+were changed by hand, deleted, or (with --unmanaged) created outside the file.
+Preview copies are never reported as unmanaged.
+
+Exit codes are stable for use in CI and scheduled jobs:
+  0  all services are in sync
+  1  the check could not be run
+  2  drift was detected
+
+Use -o json for machine-readable output.`,
+		Example: `  qspin deploy drift -f quickspin.yaml
+  qspin deploy drift -f quickspin.prod.yaml --unmanaged -o json`,
+		Args: cobra.NoArgs,
+		RunE: runDrift,
+	}
+
+	driftFiles.register(cmd)
+	cmd.Flags().BoolVar(&driftUnmanaged, "unmanaged", false, "Also report live services that are not in the file, except previews")
+
+	return cmd
+}
+
+func runDrift(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	deployment, err := loadDeployment(ctx, driftFiles, cfg, client)
+	if err != nil {
+		return err
+	}
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Checking for drift...")
+	spinner.Start()
+
+	services, err := client.ListServices(ctx)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list services: %s", err))
+		return err
+	}
+
+	report := deploypkg.DetectDrift(deployment, services, deploypkg.DriftOptions{IncludeUnmanaged: driftUnmanaged}, time.Now().UTC())
+
+	if viper.GetString("defaults.output") == string(outputpkg.FormatJSON) {
+		if err := report.RenderJSON(os.Stdout); err != nil {
+			return err
+		}
+	} else {
+		report.RenderText(os.Stdout, outputpkg.SupportsColor())
+	}
+
+	return driftResult(report)
+}
+
+// driftResult returns an ExitError with DriftExitDrift when the report found drift
+func driftResult(report *deploypkg.DriftReport) error {
+	if report.InSync {
+		return nil
+	}
+	return &ExitError{
+		Code: DriftExitDrift,
+		Err:  fmt.Errorf("drift detected in %d service(s)", len(report.Drifted())),
+	}
+}
@@ -0,0 +1,126 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+// NewExportCmd creates the deploy export command
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the live services as a deployment file",
+		Long: `Write the services of the current organization as a deployment file, to
+bring an existing environment under GitOps.
+
+Services are sorted by name. Preview copies, server-managed fields, labels
+under quickspin.io/ and config values that hold credentials are left out; the
+removed fields are listed so that they can be added back as secret
+references such as ${env:PG_PASSWORD}.
+
+If the API cannot export the organization, the file is built from the
+service list instead.`,
+		Example: `  qspin deploy export
+  qspin deploy export -o quickspin.yaml`,
+		Args: cobra.NoArgs,
+		RunE: runExport,
+	}
+
+	// Shadows the global --output format flag, as export always writes YAML
+	cmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+
+	return cmd
+}
+
+// exporter is the subset of the API client needed to export services
+type exporter interface {
+	ExportConfig(ctx context.Context, orgID string) (*models.DeploymentConfig, error)
+	ListServices(ctx context.Context) ([]models.Service, error)
+}
+
+// exportDeployment exports an organization, falling back to building the
+// file from the service list when the export endpoint is unavailable
+func exportDeployment(ctx context.Context, client exporter, orgID string) (*models.DeploymentConfig, bool, error) {
+	exported, err := client.ExportConfig(ctx, orgID)
+	if err == nil {
+		if exported.Organization == "" {
+			exported.Organization = orgID
+		}
+		return exported, false, nil
+	}
+	if !errors.Is(err, api.ErrExportUnsupported) {
+		return nil, false, err
+	}
+
+	services, err := client.ListServices(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	return deploypkg.FromServices(orgID, services), true, nil
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	orgID := cfg.GetDefaultOrganization()
+	if orgID == "" {
+		return fmt.Errorf("organization is required (use --org or set defaults.organization)")
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Exporting services...")
+	spinner.Start()
+
+	exported, fallback, err := exportDeployment(ctx, client, orgID)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to export services: %s", err))
+		return err
+	}
+	if fallback {
+		outputpkg.Warning("The API cannot export deployments; built the file from the service list")
+	}
+
+	clean, removed := deploypkg.CleanExport(exported)
+	data, err := deploypkg.FormatExport(clean)
+	if err != nil {
+		return fmt.Errorf("failed to encode deployment: %w", err)
+	}
+
+	if len(removed) > 0 {
+		outputpkg.Warning(fmt.Sprintf("Left out %d credential(s): %s. Add them back as secret references such as ${env:NAME}.",
+			len(removed), strings.Join(removed, ", ")))
+	}
+
+	if exportOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	outputpkg.Success(fmt.Sprintf("Exported %d service(s) to %s", len(clean.Services), exportOutput))
+	return nil
+}
@@ -0,0 +1,231 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultHistoryLimit is the number of deployments shown per page
+const defaultHistoryLimit = 20
+
+var (
+	historySince string
+	historyUntil string
+	historyLimit int
+	historyPage  int
+)
+
+// NewHistoryCmd creates the deploy history command
+func NewHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List past deployments",
+		Long: `List past deployments of the current organization (set with --org or
+defaults.organization), newest first, with who started each one.
+
+Use 'qspin deploy show ID' for the details of a deployment and
+'qspin deploy diff ID1 ID2' to compare two of them before a rollback.`,
+		Example: `  qspin deploy history
+  qspin deploy history --since 7d
+  qspin deploy history --since 2026-10-01 --until 2026-10-08 --page 2`,
+		Args: cobra.NoArgs,
+		RunE: runHistory,
+	}
+
+	cmd.Flags().StringVar(&historySince, "since", "", "Only show deployments newer than a duration (e.g. 24h, 7d), date or RFC3339 timestamp")
+	cmd.Flags().StringVar(&historyUntil, "until", "", "Only show deployments older than a duration, date or RFC3339 timestamp")
+	cmd.Flags().IntVar(&historyLimit, "limit", defaultHistoryLimit, "Deployments per page (0 for all)")
+	cmd.Flags().IntVar(&historyPage, "page", 1, "Page to show")
+
+	return cmd
+}
+
+// parseHistoryTime parses a --since or --until value: a duration before now
+// such as 24h or 7d, a date, or an RFC3339 timestamp
+func parseHistoryTime(flag, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s value %q (expected a duration like 24h or 7d, a date like 2026-01-31 or an RFC3339 timestamp)", flag, value)
+}
+
+// filterHistory returns the deployments created within [since, until],
+// newest first. Zero times leave that end open.
+func filterHistory(deployments []models.DeploymentResult, since, until time.Time) []models.DeploymentResult {
+	var filtered []models.DeploymentResult
+	for _, d := range deployments {
+		if !since.IsZero() && d.CreatedAt.Before(since) {
+			continue
+		}
+		if !until.IsZero() && d.CreatedAt.After(until) {
+			continue
+		}
+		filtered = append(filtered, d)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt.Time)
+	})
+	return filtered
+}
+
+// historyPageBounds returns the slice bounds of a page of total items
+func historyPageBounds(total, page, limit int) (int, int) {
+	if limit <= 0 {
+		return 0, total
+	}
+	start := min((page-1)*limit, total)
+	return start, min(start+limit, total)
+}
+
+// historyRow is a table row for a past deployment
+type historyRow struct {
+	ID        string
+	Status    string
+	Created   string
+	CreatedBy string
+	Services  string
+	Message   string
+}
+
+// deploymentStatus returns the status of a deployment, deriving one from the
+// outcome when the API does not report it
+func deploymentStatus(d *models.DeploymentResult) string {
+	if d.Status != "" {
+		return d.Status
+	}
+	if resultError(d) == nil {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+// historyRows converts deployments into table rows
+func historyRows(deployments []models.DeploymentResult) []historyRow {
+	rows := make([]historyRow, 0, len(deployments))
+	for _, d := range deployments {
+		status := deploymentStatus(&d)
+		created := "-"
+		if !d.CreatedAt.IsZero() {
+			created = d.CreatedAt.Local().Format("2006-01-02 15:04")
+		}
+		createdBy := d.CreatedBy
+		if createdBy == "" {
+			createdBy = "-"
+		}
+		services := fmt.Sprintf("%d ok", len(d.ServicesCreated))
+		if len(d.ServicesFailed) > 0 {
+			services += fmt.Sprintf(", %d failed", len(d.ServicesFailed))
+		}
+		rows = append(rows, historyRow{
+			ID:        d.ID,
+			Status:    status,
+			Created:   created,
+			CreatedBy: createdBy,
+			Services:  services,
+			Message:   d.Message,
+		})
+	}
+	return rows
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := parseHistoryTime("since", historySince, now)
+	if err != nil {
+		return err
+	}
+	until, err := parseHistoryTime("until", historyUntil, now)
+	if err != nil {
+		return err
+	}
+	if historyPage < 1 {
+		return fmt.Errorf("--page must be at least 1")
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	orgID := cfg.GetDefaultOrganization()
+	if orgID == "" {
+		return fmt.Errorf("organization is required (use --org or set defaults.organization)")
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Loading deployments...")
+	spinner.Start()
+
+	deployments, err := client.ListDeploymentsWithOptions(ctx, orgID, api.DeploymentListOptions{Since: since, Until: until})
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list deployments: %s", err))
+		return err
+	}
+
+	deployments = filterHistory(deployments, since, until)
+	if len(deployments) == 0 {
+		outputpkg.Info("No deployments found")
+		return nil
+	}
+
+	start, end := historyPageBounds(len(deployments), historyPage, historyLimit)
+	page := deployments[start:end]
+
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	if formatType == outputpkg.FormatJSON || formatType == outputpkg.FormatYAML {
+		return outputpkg.Print(formatType, page)
+	}
+
+	if len(page) == 0 {
+		outputpkg.Info(fmt.Sprintf("No deployments on page %d (%d deployment(s) found)", historyPage, len(deployments)))
+		return nil
+	}
+
+	if len(page) == len(deployments) {
+		outputpkg.Success(fmt.Sprintf("Found %d deployment(s)", len(deployments)))
+	} else {
+		outputpkg.Success(fmt.Sprintf("Showing %d-%d of %d deployment(s)", start+1, end, len(deployments)))
+	}
+	fmt.Println()
+	if err := outputpkg.PrintList(outputpkg.FormatTable, historyRows(page), []string{"ID", "STATUS", "CREATED", "BY", "SERVICES", "MESSAGE"}); err != nil {
+		return err
+	}
+	if end < len(deployments) {
+		fmt.Println()
+		outputpkg.Info(fmt.Sprintf("Use --page %d for older deployments", historyPage+1))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var lintFile string
+
+// NewLintCmd creates the deploy lint command
+func NewLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check a deployment file offline",
+		Long: `Check a deployment file without calling the API and report the line and
+column of each problem: unknown or duplicate keys, duplicate service names,
+invalid names, types, tiers and regions, and bad label keys.
+
+Values with ${...} references are not checked. Problems are printed as
+FILE:LINE:COLUMN: MESSAGE, and the command exits non-zero if there are any.
+Types and tiers come from the cached service catalog, and once it is older
+than a day unknown ones are reported as warnings instead.`,
+		Example: `  qspin deploy lint -f quickspin.yaml
+  qspin deploy lint -f quickspin.yaml -o json`,
+		Args: cobra.NoArgs,
+		RunE: runLint,
+	}
+
+	cmd.Flags().StringVarP(&lintFile, "file", "f", "", "Deployment file (- for stdin)")
+
+	return cmd
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	if lintFile == "" {
+		return fmt.Errorf("deployment file is required (use -f flag)")
+	}
+
+	cat := catalog.Cached()
+	issues, err := deploypkg.LintFile(lintFile, cat.ServiceTypes())
+	if err != nil {
+		return err
+	}
+	problems := 0
+	for i := range issues {
+		if cat.Unverified(issues[i].Err) {
+			issues[i].Warning = true
+			issues[i].Message = catalog.UnverifiedWarning(issues[i].Err)
+			continue
+		}
+		problems++
+	}
+
+	if viper.GetString("defaults.output") == string(outputpkg.FormatJSON) {
+		if issues == nil {
+			issues = []deploypkg.LintIssue{}
+		}
+		if err := outputpkg.Print(outputpkg.FormatJSON, issues); err != nil {
+			return err
+		}
+	} else if len(issues) == 0 {
+		outputpkg.Success(fmt.Sprintf("%s: no problems found", lintFile))
+	} else {
+		for _, issue := range issues {
+			fmt.Printf("%s:%s\n", lintFile, issue)
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("%s has %d problem(s)", lintFile, problems)
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
+	"github.com/quickspin/quickspin-cli/internal/tui/components"
+)
+
+// defaultParallelism bounds how many services a client-side apply changes at once
+const defaultParallelism = 4
+
+// Progress steps of each service during a client-side apply
+const (
+	stepWaiting = iota
+	stepApplying
+	stepStarting
+	stepRunning
+)
+
+// applySteps are the labels of the progress steps
+var applySteps = []string{"waiting", "applying", "starting", "running"}
+
+// serviceApplier is the subset of the API client needed for a client-side apply
+type serviceApplier interface {
+	CreateService(ctx context.Context, req api.CreateServiceRequest) (*models.Service, error)
+	UpdateService(ctx context.Context, serviceID string, req api.UpdateServiceRequest) (*models.Service, error)
+	ScaleService(ctx context.Context, serviceID string, req models.ServiceScaleRequest) (*models.Service, error)
+	GetService(ctx context.Context, serviceID string) (*models.Service, error)
+}
+
+// applyProgress shows the progress of each service. On a terminal the whole
+// block is redrawn in place; otherwise each change is printed as a line.
+type applyProgress struct {
+	mu    sync.Mutex
+	w     io.Writer
+	live  bool
+	names []string
+	steps map[string]*components.MultiStepProgress
+	notes map[string]string
+	// settled holds the status of services left as they are instead of running
+	settled map[string]string
+	drawn   int
+}
+
+// newApplyProgress creates the progress display for services in display order
+func newApplyProgress(w io.Writer, names []string, live bool) *applyProgress {
+	p := &applyProgress{
+		w:       w,
+		live:    live,
+		names:   names,
+		steps:   make(map[string]*components.MultiStepProgress, len(names)),
+		notes:   make(map[string]string, len(names)),
+		settled: make(map[string]string),
+	}
+	for _, name := range names {
+		msp := components.NewMultiStepProgress(applySteps, 0)
+		p.steps[name] = &msp
+	}
+	if live {
+		p.redraw()
+	}
+	return p
+}
+
+// set moves a service to a step with an optional note
+func (p *applyProgress) set(name string, step int, note string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.steps[name].SetStep(step)
+	p.notes[name] = note
+	p.update(name)
+}
+
+// settle marks a service that is not waited on, such as a stopped service
+// the plan left unchanged, as done with its status shown in place of running
+func (p *applyProgress) settle(name string, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.steps[name].SetStep(stepRunning)
+	p.notes[name] = status
+	p.settled[name] = status
+	p.update(name)
+}
+
+// fail marks the current step of a service as failed
+func (p *applyProgress) fail(name string, note string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.steps[name].Fail()
+	p.notes[name] = note
+	p.update(name)
+}
+
+// update shows a change to one service; the caller holds the lock
+func (p *applyProgress) update(name string) {
+	if p.live {
+		p.redraw()
+		return
+	}
+
+	msp := p.steps[name]
+	state := applySteps[msp.GetCurrentStep()]
+	note := p.notes[name]
+	if msp.IsFailed() {
+		state = "failed"
+	} else if status, ok := p.settled[name]; ok {
+		state, note = status, ""
+	}
+	line := fmt.Sprintf("%s: %s", name, state)
+	if note != "" {
+		line += " (" + note + ")"
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// redraw draws every service over the previous drawing
+func (p *applyProgress) redraw() {
+	if p.drawn > 0 {
+		fmt.Fprintf(p.w, "\033[%dA", p.drawn)
+	}
+
+	width := 0
+	for _, name := range p.names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	for _, name := range p.names {
+		line := fmt.Sprintf("%-*s  %s", width, name, p.steps[name].InlineView())
+		if note := p.notes[name]; note != "" {
+			line += "  " + note
+		}
+		fmt.Fprintf(p.w, "\033[2K%s\n", line)
+	}
+	p.drawn = len(p.names)
+}
+
+// buildUpdateRequest turns the changes of an update into an update request
+// and, for tier changes, a scale request
+func buildUpdateRequest(svcPlan deploypkg.ServicePlan, tmpl models.ServiceTemplate) (api.UpdateServiceRequest, *models.ServiceScaleRequest, error) {
+	var req api.UpdateServiceRequest
+	var scale *models.ServiceScaleRequest
+
+	for _, change := range svcPlan.Changes {
+		switch {
+		case change.Field == "tier":
+			tier := tmpl.Tier
+			scale = &models.ServiceScaleRequest{Tier: &tier}
+		case strings.HasPrefix(change.Field, "config."):
+			key := strings.TrimPrefix(change.Field, "config.")
+			if req.Config == nil {
+				req.Config = make(map[string]interface{})
+			}
+			req.Config[key] = tmpl.Config[key]
+		case strings.HasPrefix(change.Field, "labels."):
+			key := strings.TrimPrefix(change.Field, "labels.")
+			if req.Labels == nil {
+				req.Labels = make(map[string]*string)
+			}
+			// A label missing from the template is removed
+			if value, ok := tmpl.Labels[key]; ok {
+				req.Labels[key] = &value
+			} else {
+				req.Labels[key] = nil
+			}
+		default:
+			return req, nil, fmt.Errorf("cannot change the %s of an existing service", change.Field)
+		}
+	}
+
+	return req, scale, nil
+}
+
+// applyService creates or updates one service and waits for it to be running
+// when it was created or scaled, or was already starting
+func applyService(ctx context.Context, client serviceApplier, svcPlan deploypkg.ServicePlan, tmpl models.ServiceTemplate, progress *applyProgress) error {
+	serviceID := svcPlan.ServiceID
+	var scale *models.ServiceScaleRequest
+
+	switch svcPlan.Action {
+	case deploypkg.ActionCreate:
+		tier := tmpl.Tier
+		if tier == "" {
+			tier = models.ServiceTierDeveloper
+		}
+		svc, err := client.CreateService(ctx, api.CreateServiceRequest{
+			Name:   tmpl.Name,
+			Type:   tmpl.Type,
+			Tier:   tier,
+			Region: tmpl.Region,
+			Config: tmpl.Config,
+			Labels: tmpl.Labels,
+		})
+		if err != nil {
+			return err
+		}
+		serviceID = svc.ID
+	case deploypkg.ActionUpdate:
+		req, scaleReq, err := buildUpdateRequest(svcPlan, tmpl)
+		if err != nil {
+			return err
+		}
+		if req.Config != nil || req.Labels != nil {
+			if _, err := client.UpdateService(ctx, serviceID, req); err != nil {
+				return err
+			}
+		}
+		if scaleReq != nil {
+			if _, err := client.ScaleService(ctx, serviceID, *scaleReq); err != nil {
+				return err
+			}
+			scale = scaleReq
+		}
+	}
+
+	// Nothing was started unless the service was created or scaled, so only
+	// wait when it is already on its way up. A stopped service stays stopped.
+	if svcPlan.Action != deploypkg.ActionCreate && scale == nil {
+		svc, err := client.GetService(ctx, serviceID)
+		if err != nil {
+			return err
+		}
+		switch svc.Status {
+		case models.ServiceStatusCreating, models.ServiceStatusPending:
+		case models.ServiceStatusRunning:
+			progress.set(svcPlan.Name, stepRunning, "")
+			return nil
+		default:
+			progress.settle(svcPlan.Name, string(svc.Status))
+			return nil
+		}
+	}
+
+	onPoll := func(svc *models.Service) {
+		if svc.Status != models.ServiceStatusRunning {
+			progress.set(svcPlan.Name, stepStarting, string(svc.Status))
+		}
+	}
+
+	progress.set(svcPlan.Name, stepStarting, "")
+	var err error
+	if scale != nil {
+		// The service still reports running until the scale starts
+		_, err = servicepkg.WaitForScale(ctx, client, serviceID, *scale, onPoll)
+	} else {
+		_, err = servicepkg.WaitForRunning(ctx, client, serviceID, onPoll)
+	}
+	if err != nil {
+		return err
+	}
+	progress.set(svcPlan.Name, stepRunning, "")
+	return nil
+}
+
+// applyInOrder applies a plan service by service, at most parallelism at a
+// time, starting dependents only once their dependencies are running
+func applyInOrder(ctx context.Context, client serviceApplier, plan *deploypkg.Plan, deployment *models.DeploymentConfig, graph *deploypkg.Graph, parallelism int, progress *applyProgress) *models.DeploymentResult {
+	templates := make(map[string]models.ServiceTemplate, len(deployment.Services))
+	for _, tmpl := range deployment.Services {
+		templates[tmpl.Name] = tmpl
+	}
+	plans := make(map[string]deploypkg.ServicePlan, len(plan.Services))
+	for _, svcPlan := range plan.Services {
+		if svcPlan.Action != deploypkg.ActionDelete {
+			plans[svcPlan.Name] = svcPlan
+		}
+	}
+
+	for _, name := range graph.Order() {
+		if deps := graph.Dependencies(name); len(deps) > 0 {
+			progress.set(name, stepWaiting, "after "+strings.Join(deps, ", "))
+		}
+	}
+
+	errs := graph.Execute(ctx, parallelism, func(ctx context.Context, name string) error {
+		return applyService(ctx, client, plans[name], templates[name], progress)
+	}, func(event deploypkg.ApplyEvent) {
+		switch event.State {
+		case deploypkg.ApplyStarted:
+			progress.set(event.Service, stepApplying, string(plans[event.Service].Action))
+		case deploypkg.ApplyFailed:
+			progress.fail(event.Service, event.Err.Error())
+		case deploypkg.ApplySkipped:
+			progress.fail(event.Service, fmt.Sprintf("skipped: %s", event.Err))
+		}
+	})
+
+	result := &models.DeploymentResult{Success: len(errs) == 0}
+	for _, name := range graph.Order() {
+		if err, failed := errs[name]; failed {
+			result.ServicesFailed = append(result.ServicesFailed, models.DeploymentError{ServiceName: name, Error: err.Error()})
+		} else if plans[name].Action != deploypkg.ActionUnchanged {
+			result.ServicesCreated = append(result.ServicesCreated, name)
+		}
+	}
+	result.Message = fmt.Sprintf("%d service(s) applied, %d failed", len(result.ServicesCreated), len(result.ServicesFailed))
+
+	return result
+}
@@ -0,0 +1,102 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	planFiles  fileFlags
+	planPrune  bool
+	planFormat string
+)
+
+// NewPlanCmd creates the deploy plan command
+func NewPlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show what applying a deployment file would change",
+		Long: `Compare a deployment file with the live services and show which services
+would be created, updated or left unchanged, and with --prune which would be
+deleted. Nothing is changed.
+
+Use --format markdown to post the plan as a pull request comment.`,
+		Example: `  qspin deploy plan -f quickspin.yaml
+  qspin deploy plan -f quickspin.yaml --prune --format markdown > plan.md`,
+		Args: cobra.NoArgs,
+		RunE: runPlan,
+	}
+
+	planFiles.register(cmd)
+	cmd.Flags().BoolVar(&planPrune, "prune", false, "Plan the deletion of services applied from a deployment file that are no longer in it")
+	cmd.Flags().StringVar(&planFormat, "format", "", "Plan format: text, json, markdown (default: text, or json with -o json)")
+
+	return cmd
+}
+
+// buildPlan lists the live services and compares them with a deployment
+func buildPlan(ctx context.Context, client *api.Client, deployment *models.DeploymentConfig, prune bool) (*deploypkg.Plan, error) {
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Comparing with live services...")
+	spinner.Start()
+
+	services, err := client.ListServices(ctx)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list services: %s", err))
+		return nil, err
+	}
+
+	return deploypkg.BuildPlan(deployment, services, deploypkg.PlanOptions{Prune: prune}), nil
+}
+
+// planOutputFormat picks the plan format from --format or the global output format
+func planOutputFormat(format string) (string, error) {
+	if format == "" && viper.GetString("defaults.output") == string(outputpkg.FormatJSON) {
+		return deploypkg.PlanFormatJSON, nil
+	}
+	return deploypkg.ParsePlanFormat(format)
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	format, err := planOutputFormat(planFormat)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	deployment, err := loadDeployment(ctx, planFiles, cfg, client)
+	if err != nil {
+		return err
+	}
+	deployment = deploypkg.Manage(deployment)
+
+	plan, err := buildPlan(ctx, client, deployment, planPrune)
+	if err != nil {
+		return err
+	}
+	// Include the provenance label changes apply would make
+	stampProvenance(ctx, deployment, plan, planFiles.dir())
+
+	return plan.Render(os.Stdout, format, outputpkg.SupportsColor())
+}
@@ -0,0 +1,197 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	previewName     string
+	previewGCDryRun bool
+)
+
+// NewPreviewCmd creates the preview command
+func NewPreviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Manage ephemeral preview environments",
+		Long: `Create short-lived copies of the services in a deployment file, e.g. one per
+pull request.
+
+Each copy is named after the original service with the preview name as a
+suffix (cache becomes cache-pr-123) and is labeled preview=NAME and
+expires-at=TIME. Expired previews are deleted by 'qspin preview gc'.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewPreviewUpCmd())
+	cmd.AddCommand(NewPreviewDownCmd())
+	cmd.AddCommand(NewPreviewGCCmd())
+
+	return cmd
+}
+
+// NewPreviewDownCmd creates the preview down command
+func NewPreviewDownCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Delete a preview environment",
+		Long: `Delete every service labeled with the preview name.
+
+The command exits non-zero if any service cannot be deleted.`,
+		Example: `  qspin preview down --name pr-123`,
+		Args:    cobra.NoArgs,
+		RunE:    runPreviewDown,
+	}
+
+	cmd.Flags().StringVar(&previewName, "name", "", "Preview name, e.g. pr-123")
+
+	return cmd
+}
+
+// NewPreviewGCCmd creates the preview gc command
+func NewPreviewGCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete expired preview environments",
+		Long: `Delete the preview services whose expires-at label is in the past.
+
+Services without a readable expires-at label are left alone. Run it on a
+schedule, e.g. a nightly CI job.`,
+		Example: `  qspin preview gc
+  qspin preview gc --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runPreviewGC,
+	}
+
+	cmd.Flags().BoolVar(&previewGCDryRun, "dry-run", false, "List expired services without deleting them")
+
+	return cmd
+}
+
+// previewLister is the subset of the API client needed to find preview services
+type previewLister interface {
+	ListServicesWithOptions(ctx context.Context, opts api.ServiceListOptions) ([]models.Service, error)
+}
+
+// listPreviewServices lists the services carrying the preview label, or the
+// services of one preview when name is set
+func listPreviewServices(ctx context.Context, client previewLister, name string) ([]models.Service, error) {
+	selector := deploypkg.PreviewLabel
+	if name != "" {
+		selector += "=" + name
+	}
+	services, err := client.ListServicesWithOptions(ctx, api.ServiceListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		return deploypkg.PreviewServices(services, name), nil
+	}
+	return services, nil
+}
+
+// deleteServices deletes services and records the outcome of each
+func deleteServices(ctx context.Context, client serviceDeleter, services []models.Service) *models.DeploymentResult {
+	deletes := make([]deploypkg.ServicePlan, 0, len(services))
+	for _, svc := range services {
+		deletes = append(deletes, deploypkg.ServicePlan{
+			Name:      svc.Name,
+			Type:      svc.Type,
+			Action:    deploypkg.ActionDelete,
+			ServiceID: svc.ID,
+		})
+	}
+
+	result := &models.DeploymentResult{Success: true}
+	pruneServices(ctx, client, deletes, result)
+	return result
+}
+
+func runPreviewDown(cmd *cobra.Command, args []string) error {
+	if previewName == "" {
+		return fmt.Errorf("preview name is required (use --name flag)")
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	services, err := listPreviewServices(ctx, client, previewName)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list services: %s", err))
+		return err
+	}
+	if len(services) == 0 {
+		outputpkg.Info(fmt.Sprintf("No services found for preview '%s'", previewName))
+		return nil
+	}
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("Deleting %d service(s) of preview '%s'...", len(services), previewName))
+	spinner.Start()
+
+	result := deleteServices(ctx, client, services)
+	spinner.Stop()
+
+	return printResult(os.Stdout, result)
+}
+
+func runPreviewGC(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	services, err := listPreviewServices(ctx, client, "")
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list services: %s", err))
+		return err
+	}
+
+	expired := deploypkg.ExpiredPreviews(services, time.Now())
+	if len(expired) == 0 {
+		outputpkg.Info("No expired previews")
+		return nil
+	}
+
+	if previewGCDryRun {
+		fmt.Printf("%d expired preview service(s):\n", len(expired))
+		for _, svc := range expired {
+			expires, _ := deploypkg.PreviewExpiry(svc)
+			fmt.Printf("  %s  (preview %s, expired %s)\n", svc.Name, svc.Labels[deploypkg.PreviewLabel], expires.Local().Format(time.RFC3339))
+		}
+		return nil
+	}
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("Deleting %d expired service(s)...", len(expired)))
+	spinner.Start()
+
+	result := deleteServices(ctx, client, expired)
+	spinner.Stop()
+
+	return printResult(os.Stdout, result)
+}
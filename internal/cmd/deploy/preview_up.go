@@ -0,0 +1,243 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// defaultPreviewTTL is how long a preview lives unless refreshed
+const defaultPreviewTTL = 72 * time.Hour
+
+var (
+	previewFiles       fileFlags
+	previewTTL         time.Duration
+	previewFormat      string
+	previewParallelism int
+	previewTimeout     time.Duration
+	previewAllowExec   bool
+)
+
+// NewPreviewUpCmd creates the preview up command
+func NewPreviewUpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Create or refresh a preview environment",
+		Long: `Create a copy of every service in a deployment file for a preview
+environment, wait for the copies to be running and print their connection
+variables.
+
+Variable names are those of 'qspin service connect', prefixed with the
+service name from the file, e.g. CACHE_REDIS_URL. Progress is written to
+stderr, so the output can be redirected to a .env file.
+
+Running the command again for the same preview applies changes to the file
+and extends the expiry.
+
+As with 'qspin deploy apply', ${exec:command} secrets are only run with
+--allow-exec.`,
+		Example: `  qspin preview up --name pr-123 -f quickspin.yaml > .env
+  eval "$(qspin preview up --name pr-123 -f quickspin.yaml --format env)"
+  qspin preview up --name pr-123 -f quickspin.yaml --ttl 24h`,
+		Args: cobra.NoArgs,
+		RunE: runPreviewUp,
+	}
+
+	previewFiles.register(cmd)
+	cmd.Flags().StringVar(&previewName, "name", "", "Preview name, e.g. pr-123")
+	cmd.Flags().DurationVar(&previewTTL, "ttl", defaultPreviewTTL, "Time until the preview expires")
+	cmd.Flags().StringVar(&previewFormat, "format", servicepkg.ConnectFormatDotenv, "Output format: dotenv, env, json")
+	cmd.Flags().IntVar(&previewParallelism, "parallelism", defaultParallelism, "Maximum number of services to create at once")
+	cmd.Flags().DurationVar(&previewTimeout, "timeout", 10*time.Minute, "Maximum time to wait for services to be running")
+	cmd.Flags().BoolVar(&previewAllowExec, "allow-exec", false, "Allow ${exec:command} secrets to run shell commands")
+
+	return cmd
+}
+
+// checkPreviewConflicts fails if a service of a preview would take the name
+// of a live service that is not part of the same preview
+func checkPreviewConflicts(preview *models.DeploymentConfig, live []models.Service, name string) error {
+	byName := make(map[string]models.Service, len(live))
+	for _, svc := range live {
+		byName[svc.Name] = svc
+	}
+	for _, tmpl := range preview.Services {
+		if svc, ok := byName[tmpl.Name]; ok && svc.Labels[deploypkg.PreviewLabel] != name {
+			return fmt.Errorf("service %q already exists and is not part of preview %q", tmpl.Name, name)
+		}
+	}
+	return nil
+}
+
+// previewService is a service of a preview with the name it has in the deployment file
+type previewService struct {
+	Name    string
+	Service *models.Service
+}
+
+// previewServiceReader is the subset of the API client needed to read the
+// credentials of a preview
+type previewServiceReader interface {
+	previewLister
+	GetService(ctx context.Context, serviceID string) (*models.Service, error)
+}
+
+// fetchPreviewServices reads the services of a preview, with their
+// credentials, in deployment file order
+func fetchPreviewServices(ctx context.Context, client previewServiceReader, deployment *models.DeploymentConfig, name string) ([]previewService, error) {
+	live, err := listPreviewServices(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.Service, len(live))
+	for _, svc := range live {
+		byName[svc.Name] = svc
+	}
+
+	services := make([]previewService, 0, len(deployment.Services))
+	for _, tmpl := range deployment.Services {
+		svc, ok := byName[deploypkg.PreviewServiceName(tmpl.Name, name)]
+		if !ok {
+			return nil, fmt.Errorf("service %q of preview %q not found", deploypkg.PreviewServiceName(tmpl.Name, name), name)
+		}
+		if svc.Credentials == nil {
+			full, err := client.GetService(ctx, svc.ID)
+			if err != nil {
+				return nil, err
+			}
+			svc = *full
+		}
+		services = append(services, previewService{Name: tmpl.Name, Service: &svc})
+	}
+	return services, nil
+}
+
+// writePreviewEnv writes the connection variables of every service of a
+// preview, prefixed with the service's name in the deployment file
+func writePreviewEnv(w io.Writer, services []previewService, format string) error {
+	if format == servicepkg.ConnectFormatJSON {
+		env := make(map[string]string)
+		for _, s := range services {
+			if s.Service.Credentials == nil {
+				continue
+			}
+			for _, v := range servicepkg.CredentialEnv(s.Service.Type, s.Service.Credentials, s.Name+"_") {
+				env[v.Name] = v.Value
+			}
+		}
+		data, err := json.MarshalIndent(env, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode credentials: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	for _, s := range services {
+		if s.Service.Credentials == nil {
+			continue
+		}
+		out, err := servicepkg.RenderConnection(s.Service, format, s.Name+"_")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runPreviewUp(cmd *cobra.Command, args []string) error {
+	if previewName == "" {
+		return fmt.Errorf("preview name is required (use --name flag)")
+	}
+	switch previewFormat {
+	case servicepkg.ConnectFormatDotenv, servicepkg.ConnectFormatEnv, servicepkg.ConnectFormatJSON:
+	default:
+		return fmt.Errorf("invalid format %q (expected dotenv, env or json)", previewFormat)
+	}
+	if previewTTL <= 0 {
+		return fmt.Errorf("--ttl must be positive")
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	deployment, err := loadDeployment(ctx, previewFiles, cfg, client)
+	if err != nil {
+		return err
+	}
+	if err := checkExec(deployment, previewAllowExec); err != nil {
+		return err
+	}
+
+	preview, err := deploypkg.Preview(deployment, previewName, time.Now().Add(previewTTL))
+	if err != nil {
+		return err
+	}
+	graph, err := deploypkg.BuildGraph(preview)
+	if err != nil {
+		return err
+	}
+
+	live, err := client.ListServices(ctx)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list services: %s", err))
+		return err
+	}
+	if err := checkPreviewConflicts(preview, live, previewName); err != nil {
+		return err
+	}
+	plan := deploypkg.BuildPlan(preview, live, deploypkg.PlanOptions{})
+
+	resolved, err := deploypkg.NewSecretResolver(previewFiles.dir(), previewAllowExec).Resolve(ctx, preview)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to resolve secrets: %s", err))
+		return err
+	}
+
+	// Progress goes to stderr so that stdout only holds the variables
+	applyCtx, cancel := context.WithTimeout(ctx, previewTimeout)
+	progress := newApplyProgress(os.Stderr, graph.Order(), outputpkg.SupportsColor())
+	result := applyInOrder(applyCtx, client, plan, resolved, graph, previewParallelism, progress)
+	cancel()
+
+	if err := resultError(result); err != nil {
+		outputpkg.Error(fmt.Sprintf("Preview '%s' did not start", previewName))
+		renderResult(os.Stderr, result)
+		return err
+	}
+
+	services, err := fetchPreviewServices(ctx, client, deployment, previewName)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get credentials: %s", err))
+		return err
+	}
+	for _, s := range services {
+		if s.Service.Credentials == nil {
+			outputpkg.Warning(fmt.Sprintf("Service '%s' has no connection credentials yet", s.Service.Name))
+		}
+	}
+
+	return writePreviewEnv(os.Stdout, services, previewFormat)
+}
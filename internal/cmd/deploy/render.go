@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"fmt"
+	"os"
+
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var renderFiles fileFlags
+
+// NewRenderCmd creates the deploy render command
+func NewRenderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Print the fully resolved deployment file",
+		Long: `Print a deployment file with ${VAR} and ${VAR:-default} references
+interpolated and any overlays merged, exactly as apply, plan and drift see it.
+
+Variables are read from the environment, then from --env-file. Overlays are
+merged in order: services are matched by name, maps are merged key by key,
+other values are replaced and a null value removes a key.
+
+Secret references such as ${file:...} are printed as written, not resolved.`,
+		Example: `  qspin deploy render -f quickspin.yaml
+  qspin deploy render -f quickspin.yaml --overlay quickspin.prod.yaml --env-file .env.prod`,
+		Args: cobra.NoArgs,
+		RunE: runRender,
+	}
+
+	renderFiles.register(cmd)
+
+	return cmd
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	deployment, err := renderFiles.resolve()
+	if err != nil {
+		return err
+	}
+
+	if viper.GetString("defaults.output") == string(outputpkg.FormatJSON) {
+		return outputpkg.Print(outputpkg.FormatJSON, deployment)
+	}
+
+	data, err := deploypkg.Marshal(deployment)
+	if err != nil {
+		return fmt.Errorf("failed to render deployment: %w", err)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
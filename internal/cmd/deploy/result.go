@@ -0,0 +1,79 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/viper"
+)
+
+// renderResult writes a deployment result with one line per service
+func renderResult(w io.Writer, result *models.DeploymentResult) {
+	if result.ID != "" {
+		fmt.Fprintf(w, "Deployment %s", result.ID)
+		if result.Status != "" {
+			fmt.Fprintf(w, " (%s)", result.Status)
+		}
+		fmt.Fprintln(w)
+	}
+	if result.Message != "" {
+		fmt.Fprintln(w, result.Message)
+	}
+	if result.ID != "" || result.Message != "" {
+		fmt.Fprintln(w)
+	}
+
+	width := 0
+	for _, name := range result.ServicesCreated {
+		width = max(width, len(name))
+	}
+	for _, name := range result.ServicesDeleted {
+		width = max(width, len(name))
+	}
+	for _, failure := range result.ServicesFailed {
+		width = max(width, len(failure.ServiceName))
+	}
+
+	for _, name := range result.ServicesCreated {
+		fmt.Fprintf(w, "  ✓ %-*s  ok\n", width, name)
+	}
+	for _, name := range result.ServicesDeleted {
+		fmt.Fprintf(w, "  ✓ %-*s  deleted\n", width, name)
+	}
+	for _, failure := range result.ServicesFailed {
+		fmt.Fprintf(w, "  ✗ %-*s  %s\n", width, failure.ServiceName, failure.Error)
+	}
+}
+
+// resultError returns an error describing a failed or partially failed deployment
+func resultError(result *models.DeploymentResult) error {
+	failed := len(result.ServicesFailed)
+	switch {
+	case failed > 0:
+		total := failed + len(result.ServicesCreated) + len(result.ServicesDeleted)
+		return fmt.Errorf("deployment failed for %d of %d service(s)", failed, total)
+	case !result.Success:
+		if result.Message != "" {
+			return fmt.Errorf("deployment failed: %s", result.Message)
+		}
+		return fmt.Errorf("deployment failed")
+	}
+	return nil
+}
+
+// printResult prints a deployment result in the configured output format and
+// returns an error if any service failed
+func printResult(w io.Writer, result *models.DeploymentResult) error {
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	if formatType == outputpkg.FormatJSON || formatType == outputpkg.FormatYAML {
+		if err := outputpkg.Print(formatType, result); err != nil {
+			return err
+		}
+		return resultError(result)
+	}
+
+	renderResult(w, result)
+	return resultError(result)
+}
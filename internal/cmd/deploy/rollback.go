@@ -0,0 +1,69 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var rollbackYes bool
+
+// NewRollbackCmd creates the deploy rollback command
+func NewRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback DEPLOYMENT_ID",
+		Short: "Roll back a deployment",
+		Long:  "Restore the services changed by a deployment to their state before it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRollback,
+	}
+
+	cmd.Flags().BoolVarP(&rollbackYes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	deploymentID := args[0]
+	ctx := context.Background()
+
+	if !rollbackYes && !outputpkg.Confirm(fmt.Sprintf("Roll back deployment %s?", deploymentID)) {
+		outputpkg.Info("Rollback cancelled")
+		return nil
+	}
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("Rolling back deployment %s...", deploymentID))
+	spinner.Start()
+
+	result, err := client.RollbackDeployment(ctx, deploymentID)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to roll back deployment: %s", err))
+		return err
+	}
+
+	if resultError(result) == nil {
+		outputpkg.Success(fmt.Sprintf("Rolled back deployment %s", deploymentID))
+	} else {
+		outputpkg.Error(fmt.Sprintf("Rollback of deployment %s did not complete", deploymentID))
+	}
+	fmt.Println()
+
+	return printResult(os.Stdout, result)
+}
@@ -0,0 +1,45 @@
+package deploy
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewSchemaCmd creates the deploy schema command
+func NewSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for deployment files",
+		Long: `Print a JSON Schema for quickspin.yaml files so that editors can complete
+and check them as you type.
+
+Service types and tiers come from the cached service catalog when there is
+one, and from the built-in lists otherwise. Run 'qspin catalog types --refresh'
+first to include the latest ones.`,
+		Example: `  qspin deploy schema > quickspin.schema.json
+
+  # Then, at the top of quickspin.yaml (YAML language server):
+  # yaml-language-server: $schema=./quickspin.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: runSchema,
+	}
+
+	return cmd
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	cat := catalog.Cached()
+	if cat != nil && cat.Stale {
+		outputpkg.Warning("the cached service catalog is out of date, run 'qspin catalog types --refresh' to include the latest types and tiers")
+	}
+	schema := deploypkg.Schema(cat.ServiceTypes())
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
@@ -0,0 +1,110 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewShowCmd creates the deploy show command
+func NewShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show DEPLOYMENT_ID",
+		Short: "Show the details of a deployment",
+		Long: `Show when and by whom a deployment was started, the services it declared,
+and the outcome of each service with its error message.`,
+		Example: `  qspin deploy show dep_abc123
+  qspin deploy show dep_abc123 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShow,
+	}
+}
+
+// renderDeployment writes the details of a deployment
+func renderDeployment(w io.Writer, d *models.DeploymentResult) {
+	fmt.Fprintf(w, "Deployment %s\n", d.ID)
+	fmt.Fprintf(w, "  %-12s %s\n", "Status:", deploymentStatus(d))
+	if !d.CreatedAt.IsZero() {
+		fmt.Fprintf(w, "  %-12s %s\n", "Created:", d.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if d.CreatedBy != "" {
+		fmt.Fprintf(w, "  %-12s %s\n", "Created by:", d.CreatedBy)
+	}
+	if d.Message != "" {
+		fmt.Fprintf(w, "  %-12s %s\n", "Message:", d.Message)
+	}
+
+	if d.Config != nil && len(d.Config.Services) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Services declared:")
+		width := 0
+		for _, svc := range d.Config.Services {
+			width = max(width, len(svc.Name))
+		}
+		for _, svc := range d.Config.Services {
+			line := fmt.Sprintf("  %-*s  %s", width, svc.Name, svc.Type)
+			if svc.Tier != "" {
+				line += " (" + string(svc.Tier) + ")"
+			}
+			if svc.Region != "" {
+				line += "  " + svc.Region
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	if len(d.ServicesCreated) > 0 || len(d.ServicesDeleted) > 0 || len(d.ServicesFailed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Results:")
+		renderResult(w, &models.DeploymentResult{
+			ServicesCreated: d.ServicesCreated,
+			ServicesDeleted: d.ServicesDeleted,
+			ServicesFailed:  d.ServicesFailed,
+		})
+	}
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	deploymentID := args[0]
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Loading deployment...")
+	spinner.Start()
+
+	deployment, err := client.GetDeploymentStatus(ctx, deploymentID)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get deployment: %s", err))
+		return err
+	}
+	if deployment.ID == "" {
+		deployment.ID = deploymentID
+	}
+
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	if formatType == outputpkg.FormatJSON || formatType == outputpkg.FormatYAML {
+		return outputpkg.Print(formatType, deployment)
+	}
+
+	renderDeployment(os.Stdout, deployment)
+	return nil
+}
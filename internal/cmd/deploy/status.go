@@ -0,0 +1,53 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewStatusCmd creates the deploy status command
+func NewStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status DEPLOYMENT_ID",
+		Short: "Show the status of a deployment",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runStatus,
+	}
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	deploymentID := args[0]
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Loading deployment status...")
+	spinner.Start()
+
+	result, err := client.GetDeploymentStatus(ctx, deploymentID)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get deployment status: %s", err))
+		return err
+	}
+
+	if result.ID == "" {
+		result.ID = deploymentID
+	}
+	return printResult(os.Stdout, result)
+}
@@ -0,0 +1,87 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateFiles   fileFlags
+	validateOffline bool
+)
+
+// NewValidateCmd creates the deploy validate command
+func NewValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a deployment file without deploying",
+		Long: `Validate a deployment file locally and against the API without changing anything.
+
+With --offline only the local checks run.`,
+		Example: `  qspin deploy validate -f quickspin.yaml
+  qspin deploy validate -f quickspin.yaml --offline`,
+		Args: cobra.NoArgs,
+		RunE: runValidate,
+	}
+
+	validateFiles.register(cmd)
+	cmd.Flags().BoolVar(&validateOffline, "offline", false, "Only run local checks")
+
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// --offline keeps to the cached catalog
+	var fetcher catalog.Fetcher
+	if !validateOffline {
+		fetcher = client
+	}
+	deployment, err := loadDeployment(ctx, validateFiles, cfg, fetcher)
+	if err != nil {
+		return err
+	}
+
+	if validateOffline {
+		outputpkg.Success(fmt.Sprintf("%s is valid (%d service(s))", validateFiles.File, len(deployment.Services)))
+		return nil
+	}
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner("Validating deployment...")
+	spinner.Start()
+
+	result, err := client.ValidateDeployConfig(ctx, *deployment)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to validate deployment: %s", err))
+		return err
+	}
+
+	if resultError(result) == nil {
+		outputpkg.Success(fmt.Sprintf("%s is valid (%d service(s))", validateFiles.File, len(deployment.Services)))
+		return nil
+	}
+
+	outputpkg.Error(fmt.Sprintf("%s is not valid", validateFiles.File))
+	fmt.Println()
+	return printResult(os.Stdout, result)
+}
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/quickspin/quickspin-cli/internal/cmd/auth"
+	"github.com/quickspin/quickspin-cli/internal/cmd/catalog"
 	"github.com/quickspin/quickspin-cli/internal/cmd/config"
+	"github.com/quickspin/quickspin-cli/internal/cmd/deploy"
 	"github.com/quickspin/quickspin-cli/internal/cmd/service"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
 	"github.com/quickspin/quickspin-cli/internal/tui"
@@ -69,6 +72,16 @@ func Execute() error {
 	return nil
 }
 
+// ExitCode returns the process exit code for an error returned by Execute:
+// the code carried by the error if it has one, otherwise 1
+func ExitCode(err error) int {
+	var coder interface{ ExitCode() int }
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return 1
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -76,6 +89,9 @@ func init() {
 	rootCmd.AddCommand(auth.NewAuthCmd())
 	rootCmd.AddCommand(config.NewConfigCmd())
 	rootCmd.AddCommand(service.NewServiceCmd())
+	rootCmd.AddCommand(catalog.NewCatalogCmd())
+	rootCmd.AddCommand(deploy.NewDeployCmd())
+	rootCmd.AddCommand(deploy.NewPreviewCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 
 	// Global flags
@@ -89,7 +105,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&org, "org", "", "override organization context")
 
 	// Bind flags to viper
-	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	_ = viper.BindPFlag("defaults.output", rootCmd.PersistentFlags().Lookup("output"))
 	_ = viper.BindPFlag("api.url", rootCmd.PersistentFlags().Lookup("api-url"))
 	_ = viper.BindPFlag("defaults.organization", rootCmd.PersistentFlags().Lookup("org"))
 }
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// serviceTypeNames returns the service types from the cached catalog, or the
+// built-in list when there is no cache
+func serviceTypeNames(cat *catalog.Catalog) []string {
+	var names []string
+	for _, info := range cat.ServiceTypes() {
+		names = append(names, string(info.Type))
+	}
+	if len(names) > 0 {
+		return names
+	}
+	for _, t := range models.ServiceTypes {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+// tierNames returns the tiers of a service type from the cached catalog, or
+// the built-in list when the type is unknown or there is no cache
+func tierNames(cat *catalog.Catalog, serviceType models.ServiceType) []string {
+	var names []string
+	if info := cat.Type(serviceType); info != nil {
+		for _, tier := range info.Tiers {
+			names = append(names, string(tier.Tier))
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+	for _, t := range models.ServiceTiers {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+// completeServiceTypes completes a --type flag from the cached catalog
+func completeServiceTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return serviceTypeNames(catalog.Cached()), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTiers completes a --tier flag, narrowed to the tiers of --type when it is set
+func completeTiers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	serviceType, _ := cmd.Flags().GetString("type")
+	return tierNames(catalog.Cached(), models.ServiceType(serviceType)), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	connectFormat string
+	connectPrefix string
+)
+
+// NewConnectCmd creates the service connect command
+func NewConnectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connect SERVICE",
+		Short: "Show connection credentials for a service",
+		Long: `Show connection credentials for a service, identified by ID, name or unique
+name prefix.
+
+Formats:
+  text    human-readable summary (default)
+  env     shell export lines, e.g. eval "$(qspin service connect my-redis --format env)"
+  dotenv  KEY='value' lines suitable for a .env file
+  uri     the bare connection URI
+  json    credentials and environment variables as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConnect,
+	}
+
+	cmd.Flags().StringVar(&connectFormat, "format", servicepkg.ConnectFormatText, "Output format: text, env, dotenv, uri, json")
+	cmd.Flags().StringVar(&connectPrefix, "prefix", "", "Prefix prepended to every variable name (e.g. CACHE_)")
+
+	return cmd
+}
+
+func runConnect(cmd *cobra.Command, args []string) error {
+	serviceRef := args[0]
+
+	switch connectFormat {
+	case servicepkg.ConnectFormatText, servicepkg.ConnectFormatEnv, servicepkg.ConnectFormatDotenv, servicepkg.ConnectFormatURI, servicepkg.ConnectFormatJSON:
+	default:
+		return fmt.Errorf("invalid format %q (expected text, env, dotenv, uri or json)", connectFormat)
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Get service
+	service, err := getServiceByRef(ctx, client, serviceRef)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+
+	if service.Credentials == nil {
+		return fmt.Errorf("service '%s' has no connection credentials yet (status: %s)", service.Name, service.Status)
+	}
+
+	out, err := servicepkg.RenderConnection(service, connectFormat, connectPrefix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(os.Stdout, out)
+	return nil
+}
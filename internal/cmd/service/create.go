@@ -3,14 +3,19 @@ package service
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/catalog"
 	"github.com/quickspin/quickspin-cli/internal/config"
 	"github.com/quickspin/quickspin-cli/internal/models"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
 	"github.com/quickspin/quickspin-cli/internal/tui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -19,40 +24,214 @@ var (
 	createTier        string
 	createRegion      string
 	createDescription string
+	createConfig      []string
+	createLabels      []string
+	createFromFile    string
+	createPreset      string
+	createWait        bool
+	createTimeout     time.Duration
 )
 
+// defaultCreateTier is used when neither flags, file nor preset set a tier
+const defaultCreateTier = models.ServiceTier("developer")
+
 // NewCreateCmd creates the service create command
 func NewCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new service",
-		Long:  "Create a new managed microservice",
-		RunE:  runCreate,
+		Long: `Create a new managed microservice.
+
+Settings can come from a preset in ~/.quickspin/presets/NAME.yaml, a service
+definition file (--from-file) and flags. Later sources win: flags override the
+file, which overrides the preset. Config values are typed: numbers, true/false
+and lists such as [a,b] are sent as such, quote a value to force a string.`,
+		Example: `  qspin service create --name cache --type redis --config maxmemory=256mb --label team=backend
+  qspin service create --name db --type postgresql --config 'extensions=[uuid-ossp,pg_trgm]'
+  qspin service create --from-file service.yaml --tier pro
+  qspin service create --name cache --type redis --preset redis-cache`,
+		RunE: runCreate,
 	}
 
 	cmd.Flags().StringVar(&createName, "name", "", "Service name (required)")
 	cmd.Flags().StringVar(&createType, "type", "", "Service type: redis, rabbitmq, postgresql, mongodb, mysql, elasticsearch (required)")
-	cmd.Flags().StringVar(&createTier, "tier", "developer", "Service tier: starter, developer, basic, standard, pro, premium, enterprise")
+	cmd.Flags().StringVar(&createTier, "tier", string(defaultCreateTier), "Service tier: starter, developer, basic, standard, pro, premium, enterprise")
 	cmd.Flags().StringVar(&createRegion, "region", "", "Region (default: from config)")
 	cmd.Flags().StringVar(&createDescription, "description", "", "Service description")
+	cmd.Flags().StringArrayVar(&createConfig, "config", nil, "Set a config value (key=value, repeatable)")
+	cmd.Flags().StringArrayVar(&createLabels, "label", nil, "Set a label (key=value, repeatable)")
+	cmd.Flags().StringVar(&createFromFile, "from-file", "", "Read the service definition from a YAML file")
+	cmd.Flags().StringVar(&createPreset, "preset", "", "Apply a preset from ~/.quickspin/presets")
+	cmd.Flags().BoolVar(&createWait, "wait", false, "Wait until the service is running")
+	cmd.Flags().DurationVar(&createTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
+
+	_ = cmd.RegisterFlagCompletionFunc("type", completeServiceTypes)
+	_ = cmd.RegisterFlagCompletionFunc("tier", completeTiers)
 
 	return cmd
 }
 
+// createOptions holds the values given on the command line. Pointer fields
+// are nil when the flag was not set, so file and preset values can apply.
+type createOptions struct {
+	Name        string
+	Type        string
+	Tier        *string
+	Region      string
+	Description string
+	Config      []string
+	Labels      []string
+}
+
+// loadServiceTemplate reads a single service definition from a YAML file
+func loadServiceTemplate(path string) (*models.ServiceTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service file: %w", err)
+	}
+
+	var tmpl models.ServiceTemplate
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse service file %s: %w", path, err)
+	}
+	return &tmpl, nil
+}
+
+// buildCreateRequest merges a preset, a service definition file and flags
+// into a create request. Either source may be nil.
+func buildCreateRequest(opts createOptions, preset *config.Preset, tmpl *models.ServiceTemplate) (api.CreateServiceRequest, error) {
+	req := api.CreateServiceRequest{
+		Name:        opts.Name,
+		Type:        models.ServiceType(opts.Type),
+		Region:      opts.Region,
+		Description: opts.Description,
+		Config:      make(map[string]interface{}),
+		Labels:      make(map[string]string),
+	}
+	tier := defaultCreateTier
+
+	if preset != nil {
+		if preset.Tier != "" {
+			tier = models.ServiceTier(preset.Tier)
+		}
+		for k, v := range preset.Config {
+			req.Config[k] = v
+		}
+		for k, v := range preset.Labels {
+			req.Labels[k] = v
+		}
+	}
+
+	if tmpl != nil {
+		if req.Name == "" {
+			req.Name = tmpl.Name
+		}
+		if req.Type == "" {
+			req.Type = tmpl.Type
+		}
+		if tmpl.Tier != "" {
+			tier = tmpl.Tier
+		}
+		if req.Region == "" {
+			req.Region = tmpl.Region
+		}
+		for k, v := range tmpl.Config {
+			req.Config[k] = v
+		}
+		for k, v := range tmpl.Labels {
+			req.Labels[k] = v
+		}
+	}
+
+	if req.Type == "" && preset != nil {
+		req.Type = models.ServiceType(preset.Type)
+	}
+	if preset != nil && preset.Type != "" && models.ServiceType(preset.Type) != req.Type {
+		return req, fmt.Errorf("preset %q is for %s services, not %s", preset.Name, preset.Type, req.Type)
+	}
+
+	if opts.Tier != nil {
+		tier = models.ServiceTier(*opts.Tier)
+	}
+	req.Tier = tier
+
+	flagConfig, err := parseConfigFlags(opts.Config)
+	if err != nil {
+		return req, fmt.Errorf("invalid config: %w", err)
+	}
+	for k, v := range flagConfig {
+		req.Config[k] = v
+	}
+	for _, arg := range opts.Labels {
+		key, value, err := splitKeyValue(arg)
+		if err != nil {
+			return req, fmt.Errorf("invalid label: %w", err)
+		}
+		req.Labels[key] = value
+	}
+
+	if len(req.Config) == 0 {
+		req.Config = nil
+	}
+	if len(req.Labels) == 0 {
+		req.Labels = nil
+	}
+
+	if req.Name == "" {
+		return req, fmt.Errorf("service name is required (use --name flag)")
+	}
+	if req.Type == "" {
+		return req, fmt.Errorf("service type is required (use --type flag)")
+	}
+
+	return req, nil
+}
+
+// validateCreateRequest checks a create request locally before it is sent,
+// against the catalog when one is available
+func validateCreateRequest(req api.CreateServiceRequest, catalog []models.ServiceTypeInfo) error {
+	return models.NewValidator(catalog).ValidateService(req.Name, req.Type, req.Tier, req.Region)
+}
+
 func runCreate(cmd *cobra.Command, args []string) error {
 	// Check if we should use TUI mode
 	outputFormat := viper.GetString("defaults.output")
-	if outputpkg.ShouldUseTUI(outputFormat) && createName == "" && createType == "" {
+	if outputpkg.ShouldUseTUI(outputFormat) && createName == "" && createType == "" && createFromFile == "" {
 		// Launch TUI service create wizard
 		return tui.LaunchView(tui.ViewServiceCreate)
 	}
 
-	// Traditional CLI mode - require name and type
-	if createName == "" {
-		return fmt.Errorf("service name is required (use --name flag)")
+	opts := createOptions{
+		Name:        createName,
+		Type:        createType,
+		Region:      createRegion,
+		Description: createDescription,
+		Config:      createConfig,
+		Labels:      createLabels,
+	}
+	if cmd.Flags().Changed("tier") {
+		opts.Tier = &createTier
+	}
+
+	var preset *config.Preset
+	if createPreset != "" {
+		var err error
+		if preset, err = config.LoadPreset(createPreset); err != nil {
+			return err
+		}
 	}
-	if createType == "" {
-		return fmt.Errorf("service type is required (use --type flag)")
+
+	var tmpl *models.ServiceTemplate
+	if createFromFile != "" {
+		var err error
+		if tmpl, err = loadServiceTemplate(createFromFile); err != nil {
+			return err
+		}
+	}
+
+	req, err := buildCreateRequest(opts, preset, tmpl)
+	if err != nil {
+		return err
 	}
 
 	ctx := context.Background()
@@ -66,25 +245,26 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Create API client
 	client := api.NewClient(cfg)
 
-	// Use region from config if not provided
-	region := createRegion
-	if region == "" {
-		region = viper.GetString("defaults.region")
+	cat := catalog.Current(ctx, client)
+	if err := validateCreateRequest(req, cat.ServiceTypes()); err != nil {
+		if !cat.Unverified(err) {
+			return err
+		}
+		outputpkg.Warning(catalog.UnverifiedWarning(err))
+		// The region is checked after the type and tier
+		if err := models.ValidateRegion(req.Region); err != nil {
+			return err
+		}
 	}
 
-	// Create service request
-	req := api.CreateServiceRequest{
-		Name:        createName,
-		Type:        models.ServiceType(createType),
-		Tier:        models.ServiceTier(createTier),
-		Region:      region,
-		Description: createDescription,
+	// Use region from config if not provided
+	if req.Region == "" {
+		req.Region = viper.GetString("defaults.region")
 	}
 
 	// Show spinner
-	spinner := outputpkg.NewSpinner(fmt.Sprintf("Creating %s service '%s'...", createType, createName))
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("Creating %s service '%s'...", req.Type, req.Name))
 	spinner.Start()
-
 	// Create service
 	service, err := client.CreateService(ctx, req)
 	spinner.Stop()
@@ -96,6 +276,15 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// Success message
 	outputpkg.Success(fmt.Sprintf("Successfully created service '%s'", service.Name))
+
+	if createWait {
+		service, err = waitWithProgress(ctx, client, service, servicepkg.Condition{Status: models.ServiceStatusRunning}, createTimeout)
+		if err != nil {
+			outputpkg.Error(err.Error())
+			return err
+		}
+		outputpkg.Success(fmt.Sprintf("Service '%s' is running", service.Name))
+	}
 	fmt.Println()
 
 	formatType := outputpkg.Format(viper.GetString("defaults.output"))
@@ -3,35 +3,41 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/api"
 	"github.com/quickspin/quickspin-cli/internal/config"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
 	"github.com/spf13/cobra"
 )
 
 var (
-	deleteForce bool
+	deleteForce   bool
+	deleteWait    bool
+	deleteTimeout time.Duration
 )
 
 // NewDeleteCmd creates the service delete command
 func NewDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "delete SERVICE_ID",
+		Use:     "delete SERVICE",
 		Aliases: []string{"rm", "remove"},
 		Short:   "Delete a service",
-		Long:    "Delete a managed microservice",
+		Long:    "Delete a managed microservice, identified by ID, name or unique name prefix",
 		Args:    cobra.ExactArgs(1),
 		RunE:    runDelete,
 	}
 
 	cmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&deleteWait, "wait", false, "Wait until the service is fully deleted")
+	cmd.Flags().DurationVar(&deleteTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
 
 	return cmd
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
-	serviceID := args[0]
+	serviceRef := args[0]
 
 	ctx := context.Background()
 
@@ -45,7 +51,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	client := api.NewClient(cfg)
 
 	// Get service details first
-	service, err := client.GetService(ctx, serviceID)
+	service, err := getServiceByRef(ctx, client, serviceRef)
 	if err != nil {
 		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
 		return err
@@ -53,12 +59,8 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	// Confirm deletion unless --force flag is used
 	if !deleteForce {
-		fmt.Printf("Are you sure you want to delete service '%s' (%s)? This action cannot be undone.\n", service.Name, serviceID)
-		fmt.Print("Type 'yes' to confirm: ")
-		var confirmation string
-		fmt.Scanln(&confirmation)
-
-		if confirmation != "yes" {
+		message := fmt.Sprintf("Are you sure you want to delete service '%s' (%s)? This action cannot be undone.", service.Name, service.ID)
+		if !outputpkg.Confirm(message) {
 			outputpkg.Info("Deletion cancelled")
 			return nil
 		}
@@ -69,7 +71,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	spinner.Start()
 
 	// Delete service
-	err = client.DeleteService(ctx, serviceID)
+	err = client.DeleteService(ctx, service.ID)
 	spinner.Stop()
 
 	if err != nil {
@@ -77,6 +79,13 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if deleteWait {
+		if _, err := waitWithProgress(ctx, client, service, servicepkg.Condition{Deleted: true}, deleteTimeout); err != nil {
+			outputpkg.Error(err.Error())
+			return err
+		}
+	}
+
 	// Success message
 	outputpkg.Success(fmt.Sprintf("Successfully deleted service '%s'", service.Name))
 
@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/quickspin/quickspin-cli/internal/api"
 	"github.com/quickspin/quickspin-cli/internal/config"
+	deploypkg "github.com/quickspin/quickspin-cli/internal/deploy"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
 	"github.com/quickspin/quickspin-cli/internal/tui"
 	"github.com/spf13/cobra"
@@ -15,10 +18,10 @@ import (
 // NewDescribeCmd creates the service describe command
 func NewDescribeCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "describe SERVICE_ID",
+		Use:     "describe SERVICE",
 		Aliases: []string{"get", "show"},
 		Short:   "Get service details",
-		Long:    "Get detailed information about a specific service",
+		Long:    "Get detailed information about a specific service, identified by ID, name or unique name prefix",
 		Args:    cobra.ExactArgs(1),
 		RunE:    runDescribe,
 	}
@@ -27,13 +30,13 @@ func NewDescribeCmd() *cobra.Command {
 }
 
 func runDescribe(cmd *cobra.Command, args []string) error {
-	serviceID := args[0]
+	serviceRef := args[0]
 
 	// Check if we should use TUI mode
 	outputFormat := viper.GetString("defaults.output")
 	if outputpkg.ShouldUseTUI(outputFormat) {
 		// Launch TUI service detail view
-		// Note: We'd need to pass the serviceID to the view
+		// Note: We'd need to pass the service reference to the view
 		return tui.LaunchView(tui.ViewServiceDetail)
 	}
 
@@ -54,7 +57,7 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 	spinner.Start()
 
 	// Get service
-	service, err := client.GetService(ctx, serviceID)
+	service, err := getServiceByRef(ctx, client, serviceRef)
 	spinner.Stop()
 
 	if err != nil {
@@ -62,8 +65,35 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Services that don't report replicas run a single one
+	service.Replicas = service.ReplicaCount()
+
 	// Display service details
 	fmt.Println()
 	formatType := outputpkg.Format(viper.GetString("defaults.output"))
-	return outputpkg.Print(formatType, service)
+	if err := outputpkg.Print(formatType, service); err != nil {
+		return err
+	}
+
+	// The labels already carry the provenance in JSON and YAML
+	if formatType != outputpkg.FormatJSON && formatType != outputpkg.FormatYAML {
+		renderProvenance(os.Stdout, service.Labels)
+	}
+	return nil
+}
+
+// renderProvenance writes the git commit a service was last deployed from,
+// if its labels record one
+func renderProvenance(w io.Writer, labels map[string]string) {
+	provenance := deploypkg.ProvenanceFromLabels(labels)
+	if provenance == nil {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%-15s %s\n", "Deployed from:", provenance)
+	if provenance.Repo != "" {
+		fmt.Fprintf(w, "%-15s %s\n", "Repository:", provenance.Repo)
+	}
+	fmt.Fprintf(w, "%-15s %s\n", "Commit:", provenance.Commit)
 }
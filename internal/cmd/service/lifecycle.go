@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// lifecycleAction describes a stop/start/restart command
+type lifecycleAction struct {
+	name   string
+	short  string
+	gerund string
+	past   string
+	// noop is the status in which the action has nothing to do ("" if always applicable)
+	noop models.ServiceStatus
+	call func(ctx context.Context, client *api.Client, serviceID string) (*models.Service, error)
+
+	selector string
+	force    bool
+}
+
+// NewStopCmd creates the service stop command
+func NewStopCmd() *cobra.Command {
+	return newLifecycleCmd(&lifecycleAction{
+		name:   "stop",
+		short:  "Stop a service",
+		gerund: "Stopping",
+		past:   "stopped",
+		noop:   models.ServiceStatusStopped,
+		call: func(ctx context.Context, client *api.Client, serviceID string) (*models.Service, error) {
+			return client.StopService(ctx, serviceID)
+		},
+	})
+}
+
+// NewStartCmd creates the service start command
+func NewStartCmd() *cobra.Command {
+	return newLifecycleCmd(&lifecycleAction{
+		name:   "start",
+		short:  "Start a stopped service",
+		gerund: "Starting",
+		past:   "started",
+		noop:   models.ServiceStatusRunning,
+		call: func(ctx context.Context, client *api.Client, serviceID string) (*models.Service, error) {
+			return client.StartService(ctx, serviceID)
+		},
+	})
+}
+
+// NewRestartCmd creates the service restart command
+func NewRestartCmd() *cobra.Command {
+	return newLifecycleCmd(&lifecycleAction{
+		name:   "restart",
+		short:  "Restart a service",
+		gerund: "Restarting",
+		past:   "restarted",
+		call: func(ctx context.Context, client *api.Client, serviceID string) (*models.Service, error) {
+			return client.RestartService(ctx, serviceID)
+		},
+	})
+}
+
+func newLifecycleCmd(action *lifecycleAction) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action.name + " [SERVICE]",
+		Short: action.short,
+		Long: fmt.Sprintf(`%s a service identified by ID, name or unique name prefix.
+
+Use --selector to %s every service matching a label selector instead, e.g.
+  qspin service %s -l env=dev`, action.short, action.name, action.name),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return action.run(args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&action.selector, "selector", "l", "", "Apply to all services matching a label selector")
+	cmd.Flags().BoolVarP(&action.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func (a *lifecycleAction) run(args []string) error {
+	if len(args) == 0 && a.selector == "" {
+		return fmt.Errorf("specify a service or a label selector (--selector)")
+	}
+	if len(args) > 0 && a.selector != "" {
+		return fmt.Errorf("specify either a service or a label selector, not both")
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	if a.selector != "" {
+		return a.runBulk(ctx, client)
+	}
+
+	service, err := ResolveService(ctx, client, args[0])
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+
+	if a.noop != "" && service.Status == a.noop {
+		outputpkg.Info(fmt.Sprintf("Service '%s' is already %s", service.Name, a.noop))
+		return nil
+	}
+
+	if !a.force && !outputpkg.Confirm(fmt.Sprintf("Are you sure you want to %s service '%s' (%s)?", a.name, service.Name, service.ID)) {
+		outputpkg.Info(fmt.Sprintf("%s cancelled", capitalize(a.name)))
+		return nil
+	}
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("%s service '%s'...", a.gerund, service.Name))
+	spinner.Start()
+
+	result, err := a.call(ctx, client, service.ID)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to %s service: %s", a.name, err))
+		return err
+	}
+
+	// Success message
+	outputpkg.Success(fmt.Sprintf("Successfully %s service '%s'", a.past, result.Name))
+	fmt.Println()
+
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	return outputpkg.Print(formatType, result)
+}
+
+// runBulk applies the action to every service matching the selector
+func (a *lifecycleAction) runBulk(ctx context.Context, client *api.Client) error {
+	selector, err := ParseSelector(a.selector)
+	if err != nil {
+		return err
+	}
+	// An empty selector would match every service in the organization
+	if selector.Empty() {
+		return fmt.Errorf("label selector %q is empty", a.selector)
+	}
+
+	spinner := outputpkg.NewSpinner("Loading services...")
+	spinner.Start()
+	services, err := client.ListServicesWithOptions(ctx, api.ServiceListOptions{LabelSelector: selector.String()})
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to list services: %s", err))
+		return err
+	}
+
+	targets := a.targets(services, selector)
+	if len(targets) == 0 {
+		outputpkg.Info(fmt.Sprintf("No services matching '%s' need to be %s", a.selector, a.past))
+		return nil
+	}
+
+	fmt.Printf("The following %d service(s) will be %s:\n", len(targets), a.past)
+	for _, svc := range targets {
+		fmt.Printf("  %s (%s, %s)\n", svc.Name, svc.ID, svc.Status)
+	}
+	if !a.force && !outputpkg.Confirm(fmt.Sprintf("Are you sure you want to %s %d service(s)?", a.name, len(targets))) {
+		outputpkg.Info(fmt.Sprintf("%s cancelled", capitalize(a.name)))
+		return nil
+	}
+
+	var failed []string
+	for _, svc := range targets {
+		spinner := outputpkg.NewSpinner(fmt.Sprintf("%s service '%s'...", a.gerund, svc.Name))
+		spinner.Start()
+		_, err := a.call(ctx, client, svc.ID)
+		spinner.Stop()
+
+		if err != nil {
+			outputpkg.Error(fmt.Sprintf("Failed to %s service '%s': %s", a.name, svc.Name, err))
+			failed = append(failed, svc.Name)
+			continue
+		}
+		outputpkg.Success(fmt.Sprintf("Successfully %s service '%s'", a.past, svc.Name))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to %s %d of %d service(s): %s", a.name, len(failed), len(targets), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// targets returns the services matching the selector that the action applies to
+func (a *lifecycleAction) targets(services []models.Service, selector Selector) []models.Service {
+	var targets []models.Service
+	for _, svc := range services {
+		if !selector.Matches(svc.Labels) {
+			continue
+		}
+		if a.noop != "" && svc.Status == a.noop {
+			continue
+		}
+		targets = append(targets, svc)
+	}
+	return targets
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
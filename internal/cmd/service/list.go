@@ -3,32 +3,175 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/quickspin/quickspin-cli/internal/api"
 	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
 	"github.com/quickspin/quickspin-cli/internal/tui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var (
+	listTypes    []string
+	listStatuses []string
+	listRegions  []string
+	listTiers    []string
+	listSelector string
+	listSortBy   string
+)
+
 // NewListCmd creates the service list command
 func NewListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List all services",
-		Long:    "List all managed services for your account",
-		RunE:    runList,
+		Long: `List all managed services for your account.
+
+Filters are sent to the API and applied locally as well, so they work
+against servers that don't support them. Label selectors use kubectl
+syntax, e.g. -l 'team=backend,env!=prod' or -l 'env in (dev,staging)'.`,
+		RunE: runList,
 	}
 
+	cmd.Flags().StringSliceVar(&listTypes, "type", nil, "Filter by service type (repeatable or comma-separated)")
+	cmd.Flags().StringSliceVar(&listStatuses, "status", nil, "Filter by status (repeatable or comma-separated)")
+	cmd.Flags().StringSliceVar(&listRegions, "region", nil, "Filter by region (repeatable or comma-separated)")
+	cmd.Flags().StringSliceVar(&listTiers, "tier", nil, "Filter by tier (repeatable or comma-separated)")
+	cmd.Flags().StringVarP(&listSelector, "selector", "l", "", "Label selector (e.g. team=backend,env!=prod)")
+	cmd.Flags().StringVar(&listSortBy, "sort-by", "", "Sort by: name, created, tier")
+
 	return cmd
 }
 
+// serviceFilter holds the client-side service filters
+type serviceFilter struct {
+	Types    []string
+	Statuses []string
+	Regions  []string
+	Tiers    []string
+	Selector Selector
+}
+
+// Empty reports whether the filter matches every service
+func (f serviceFilter) Empty() bool {
+	return len(f.Types) == 0 && len(f.Statuses) == 0 && len(f.Regions) == 0 &&
+		len(f.Tiers) == 0 && f.Selector.Empty()
+}
+
+// Match reports whether a service passes the filter
+func (f serviceFilter) Match(svc models.Service) bool {
+	return matchesAny(f.Types, string(svc.Type)) &&
+		matchesAny(f.Statuses, string(svc.Status)) &&
+		matchesAny(f.Regions, svc.Region) &&
+		matchesAny(f.Tiers, string(svc.Tier)) &&
+		f.Selector.Matches(svc.Labels)
+}
+
+// Apply returns the services that pass the filter
+func (f serviceFilter) Apply(services []models.Service) []models.Service {
+	if f.Empty() {
+		return services
+	}
+	var result []models.Service
+	for _, svc := range services {
+		if f.Match(svc) {
+			result = append(result, svc)
+		}
+	}
+	return result
+}
+
+// listOptions converts the filter into server-side list options
+func (f serviceFilter) listOptions() api.ServiceListOptions {
+	return api.ServiceListOptions{
+		Types:         f.Types,
+		Statuses:      f.Statuses,
+		Regions:       f.Regions,
+		Tiers:         f.Tiers,
+		LabelSelector: f.Selector.String(),
+	}
+}
+
+// matchesAny reports whether value equals one of the allowed values (case-insensitively);
+// an empty list allows everything
+func matchesAny(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceLess returns the ordering for a --sort-by key, or nil for no sorting
+func serviceLess(by string) (func(a, b models.Service) bool, error) {
+	switch by {
+	case "":
+		return nil, nil
+	case "name":
+		return func(a, b models.Service) bool { return a.Name < b.Name }, nil
+	case "created", "created_at", "age":
+		return func(a, b models.Service) bool {
+			if a.CreatedAt.Equal(b.CreatedAt.Time) {
+				return a.Name < b.Name
+			}
+			return a.CreatedAt.Before(b.CreatedAt.Time)
+		}, nil
+	case "tier":
+		return func(a, b models.Service) bool {
+			if a.Tier.Rank() == b.Tier.Rank() {
+				return a.Name < b.Name
+			}
+			return a.Tier.Rank() < b.Tier.Rank()
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid --sort-by value %q (expected name, created or tier)", by)
+	}
+}
+
+// validSortKey checks a --sort-by value before any services are fetched
+func validSortKey(by string) error {
+	_, err := serviceLess(by)
+	return err
+}
+
+// sortServices sorts services in place by name, created or tier
+func sortServices(services []models.Service, by string) error {
+	less, err := serviceLess(by)
+	if err != nil || less == nil {
+		return err
+	}
+	sort.SliceStable(services, func(i, j int) bool { return less(services[i], services[j]) })
+	return nil
+}
+
 func runList(cmd *cobra.Command, args []string) error {
+	selector, err := ParseSelector(listSelector)
+	if err != nil {
+		return err
+	}
+	if err := validSortKey(listSortBy); err != nil {
+		return err
+	}
+	filter := serviceFilter{
+		Types:    listTypes,
+		Statuses: listStatuses,
+		Regions:  listRegions,
+		Tiers:    listTiers,
+		Selector: selector,
+	}
+
 	// Check if we should use TUI mode
 	outputFormat := viper.GetString("defaults.output")
-	if outputpkg.ShouldUseTUI(outputFormat) {
+	if outputpkg.ShouldUseTUI(outputFormat) && filter.Empty() && listSortBy == "" {
 		// Launch TUI service list
 		return tui.LaunchView(tui.ViewServiceList)
 	}
@@ -50,7 +193,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	spinner.Start()
 
 	// List services
-	services, err := client.ListServices(ctx)
+	services, err := client.ListServicesWithOptions(ctx, filter.listOptions())
 	spinner.Stop()
 
 	if err != nil {
@@ -58,6 +201,12 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Filter locally in case the API ignored any of the filters
+	services = filter.Apply(services)
+	if err := sortServices(services, listSortBy); err != nil {
+		return err
+	}
+
 	if len(services) == 0 {
 		outputpkg.Info("No services found")
 		return nil
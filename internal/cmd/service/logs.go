@@ -2,47 +2,98 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/api"
 	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
 	"github.com/quickspin/quickspin-cli/internal/tui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 var (
-	logsLines int
+	logsLines    int
+	logsFollow   bool
+	logsSince    string
+	logsLevel    string
+	logsGrep     string
+	logsInterval time.Duration
 )
 
+// logLevels orders log levels by severity for --level filtering
+var logLevels = map[string]int{
+	"trace":    0,
+	"debug":    1,
+	"info":     2,
+	"notice":   2,
+	"warn":     3,
+	"warning":  3,
+	"error":    4,
+	"fatal":    5,
+	"critical": 5,
+}
+
 // NewLogsCmd creates the service logs command
 func NewLogsCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "logs SERVICE_ID",
+		Use:   "logs SERVICE",
 		Short: "View service logs",
-		Long:  "View logs for a specific service",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runLogs,
+		Long: `View logs for a specific service, identified by ID, name or unique name prefix.
+
+Use --follow to stream new log entries as they arrive. Streaming uses
+server-sent events when the API supports them and falls back to polling
+otherwise. Press Ctrl-C to stop.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runLogs,
 	}
 
 	cmd.Flags().IntVarP(&logsLines, "lines", "n", 100, "Number of log lines to retrieve")
+	cmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Stream new log entries until interrupted")
+	cmd.Flags().StringVar(&logsSince, "since", "", "Only show entries newer than a duration (e.g. 10m) or RFC3339 timestamp")
+	cmd.Flags().StringVar(&logsLevel, "level", "", "Only show entries at or above this level: debug, info, warn, error")
+	cmd.Flags().StringVar(&logsGrep, "grep", "", "Only show entries whose message matches this regular expression")
+	cmd.Flags().DurationVar(&logsInterval, "interval", 2*time.Second, "Polling interval when streaming is unavailable")
+
+	// Accept --tail as an alias for --lines
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "tail" {
+			name = "lines"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	return cmd
 }
 
 func runLogs(cmd *cobra.Command, args []string) error {
-	serviceID := args[0]
+	serviceRef := args[0]
 
 	// Check if we should use TUI mode
 	outputFormat := viper.GetString("defaults.output")
-	if outputpkg.ShouldUseTUI(outputFormat) {
+	if outputpkg.ShouldUseTUI(outputFormat) && !logsFollow {
 		// Launch TUI service logs view
 		return tui.LaunchView(tui.ViewServiceLogs)
 	}
 
+	filter, err := newLogFilter(logsSince, logsLevel, logsGrep, time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Traditional CLI mode
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Load config
 	cfg, err := config.LoadConfig()
@@ -53,12 +104,26 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	// Create API client
 	client := api.NewClient(cfg)
 
+	// Resolve service reference
+	service, err := ResolveService(ctx, client, serviceRef)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+	serviceID := service.ID
+
+	query := api.LogQuery{
+		Lines: logsLines,
+		Since: filter.since,
+		Level: logsLevel,
+	}
+
 	// Show spinner
 	spinner := outputpkg.NewSpinner(fmt.Sprintf("Loading last %d log lines...", logsLines))
 	spinner.Start()
 
 	// Get service logs
-	logs, err := client.GetServiceLogs(ctx, serviceID, logsLines)
+	logs, err := client.QueryServiceLogs(ctx, serviceID, query)
 	spinner.Stop()
 
 	if err != nil {
@@ -66,36 +131,207 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if len(logs) == 0 {
-		outputpkg.Info("No logs found")
-		return nil
+	cursor := newLogCursor()
+	var matched []models.ServiceLogEntry
+	for _, entry := range logs {
+		if cursor.accept(entry) && filter.match(entry) {
+			matched = append(matched, entry)
+		}
 	}
 
-	// Display logs
-	outputpkg.Success(fmt.Sprintf("Retrieved %d log entries", len(logs)))
-	fmt.Println()
+	if !logsFollow {
+		if len(matched) == 0 {
+			outputpkg.Info("No logs found")
+			return nil
+		}
+
+		// Display logs
+		outputpkg.Success(fmt.Sprintf("Retrieved %d log entries", len(matched)))
+		fmt.Println()
+	}
 
 	// Print logs in chronological order
-	for _, log := range logs {
-		timestamp := log.Timestamp.Format("2006-01-02 15:04:05")
-		level := log.Level
-		message := log.Message
-
-		// Format log level prefix
-		var prefix string
-		switch level {
-		case "error":
-			prefix = "\033[31m[ERROR]\033[0m" // Red
-		case "warning", "warn":
-			prefix = "\033[33m[WARN]\033[0m" // Yellow
-		case "info":
-			prefix = "\033[36m[INFO]\033[0m" // Cyan
-		default:
-			prefix = fmt.Sprintf("[%s]", level)
+	for _, entry := range matched {
+		printLogEntry(entry)
+	}
+
+	if !logsFollow {
+		return nil
+	}
+
+	return followLogs(ctx, client, serviceID, query, cursor, filter)
+}
+
+// followLogs streams new log entries until the context is cancelled, falling
+// back to polling when the API does not support server-sent events
+func followLogs(ctx context.Context, client *api.Client, serviceID string, query api.LogQuery, cursor *logCursor, filter *logFilter) error {
+	emit := func(entry models.ServiceLogEntry) {
+		if cursor.accept(entry) && filter.match(entry) {
+			printLogEntry(entry)
+		}
+	}
+
+	streaming := true
+	for {
+		query.Since = cursor.since
+		if query.Since.IsZero() {
+			query.Since = filter.since
+		}
+
+		if streaming {
+			err := client.StreamServiceLogs(ctx, serviceID, query, emit)
+			switch {
+			case ctx.Err() != nil:
+				return nil
+			case errors.Is(err, api.ErrStreamingUnsupported):
+				streaming = false
+				continue
+			case servicepkg.IsAuthError(err):
+				return err
+			case err != nil:
+				outputpkg.Warning(fmt.Sprintf("Log stream interrupted, reconnecting: %s", err))
+			}
+		} else {
+			logs, err := client.QueryServiceLogs(ctx, serviceID, query)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if servicepkg.IsAuthError(err) {
+				return err
+			}
+			if err != nil {
+				outputpkg.Warning(fmt.Sprintf("Failed to poll logs: %s", err))
+			}
+			for _, entry := range logs {
+				emit(entry)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logsInterval):
 		}
+	}
+}
+
+// printLogEntry prints a single log entry with a colored level prefix
+func printLogEntry(entry models.ServiceLogEntry) {
+	timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
+	level := entry.Level
+	message := entry.Message
+
+	// Format log level prefix
+	var prefix string
+	switch level {
+	case "error":
+		prefix = "\033[31m[ERROR]\033[0m" // Red
+	case "warning", "warn":
+		prefix = "\033[33m[WARN]\033[0m" // Yellow
+	case "info":
+		prefix = "\033[36m[INFO]\033[0m" // Cyan
+	default:
+		prefix = fmt.Sprintf("[%s]", level)
+	}
+
+	fmt.Printf("%s %s %s\n", timestamp, prefix, message)
+}
+
+// logFilter applies the --since, --level and --grep flags client-side, so
+// filtering works even when the API ignores the corresponding query parameters
+type logFilter struct {
+	since    time.Time
+	minLevel int
+	pattern  *regexp.Regexp
+}
+
+// newLogFilter parses the log filter flags
+func newLogFilter(since, level, grep string, now time.Time) (*logFilter, error) {
+	f := &logFilter{minLevel: -1}
+
+	if since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			f.since = now.Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+			f.since = t
+		} else {
+			return nil, fmt.Errorf("invalid --since value %q (expected a duration like 10m or an RFC3339 timestamp)", since)
+		}
+	}
+
+	if level != "" {
+		severity, ok := logLevels[strings.ToLower(level)]
+		if !ok {
+			return nil, fmt.Errorf("invalid --level value %q (expected debug, info, warn or error)", level)
+		}
+		f.minLevel = severity
+	}
+
+	if grep != "" {
+		pattern, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+		f.pattern = pattern
+	}
 
-		fmt.Printf("%s %s %s\n", timestamp, prefix, message)
+	return f, nil
+}
+
+// match reports whether an entry passes all filters
+func (f *logFilter) match(entry models.ServiceLogEntry) bool {
+	if !f.since.IsZero() && !entry.Timestamp.IsZero() && entry.Timestamp.Before(f.since) {
+		return false
+	}
+	if f.minLevel >= 0 {
+		// Unknown levels are shown rather than silently dropped
+		if severity, ok := logLevels[strings.ToLower(entry.Level)]; ok && severity < f.minLevel {
+			return false
+		}
+	}
+	if f.pattern != nil && !f.pattern.MatchString(entry.Message) {
+		return false
+	}
+	return true
+}
+
+// logCursor tracks the newest timestamp seen so entries repeated across
+// polls or stream reconnects are printed only once
+type logCursor struct {
+	since time.Time
+	// seen holds the entries at the cursor timestamp
+	seen map[string]struct{}
+	// untimed holds entries without a timestamp, which every poll may repeat
+	untimed map[string]struct{}
+}
+
+func newLogCursor() *logCursor {
+	return &logCursor{seen: make(map[string]struct{}), untimed: make(map[string]struct{})}
+}
+
+// accept reports whether an entry is new, advancing the cursor
+func (c *logCursor) accept(entry models.ServiceLogEntry) bool {
+	key := entry.Level + "\x00" + entry.Source + "\x00" + entry.Message
+	ts := entry.Timestamp.Time
+
+	switch {
+	case ts.IsZero():
+		if _, ok := c.untimed[key]; ok {
+			return false
+		}
+		c.untimed[key] = struct{}{}
+	case ts.Equal(c.since):
+		// Entries sharing the cursor timestamp are told apart by content
+		if _, ok := c.seen[key]; ok {
+			return false
+		}
+		c.seen[key] = struct{}{}
+	case ts.Before(c.since):
+		return false
+	default:
+		c.since = ts
+		c.seen = map[string]struct{}{key: {}}
 	}
 
-	return nil
+	return true
 }
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	metricsWatch    bool
+	metricsInterval time.Duration
+	metricsJSON     bool
+	metricsHistory  int
+)
+
+// NewMetricsCmd creates the service metrics command
+func NewMetricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics SERVICE",
+		Short: "Show resource usage metrics for a service",
+		Long: `Show CPU, memory, storage and network metrics for a service.
+
+With --watch the metrics are polled at an interval and drawn as rolling
+sparklines, with network throughput derived from successive samples.
+With --json each sample is written as one JSON object per line.`,
+		Example: `  qspin service metrics my-redis
+  qspin service metrics my-redis --watch --interval 10s
+  qspin service metrics my-redis --watch --json | jq .cpu_percent`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMetrics,
+	}
+
+	cmd.Flags().BoolVarP(&metricsWatch, "watch", "w", false, "Poll metrics until interrupted")
+	cmd.Flags().DurationVar(&metricsInterval, "interval", 5*time.Second, "Polling interval for --watch")
+	cmd.Flags().BoolVar(&metricsJSON, "json", false, "Write one JSON object per sample")
+	cmd.Flags().IntVar(&metricsHistory, "history", 40, "Number of samples shown in sparklines")
+
+	return cmd
+}
+
+// metricsSample is a single metrics reading with derived network rates
+type metricsSample struct {
+	ServiceID      string                 `json:"service_id"`
+	Timestamp      time.Time              `json:"timestamp"`
+	CPU            float64                `json:"cpu_percent"`
+	Memory         float64                `json:"memory_percent"`
+	Storage        float64                `json:"storage_percent"`
+	BytesIn        int64                  `json:"bytes_in"`
+	BytesOut       int64                  `json:"bytes_out"`
+	BytesInPerSec  *float64               `json:"bytes_in_per_sec,omitempty"`
+	BytesOutPerSec *float64               `json:"bytes_out_per_sec,omitempty"`
+	Custom         map[string]interface{} `json:"custom,omitempty"`
+}
+
+// metricsSeries keeps a rolling window of samples
+type metricsSeries struct {
+	size    int
+	samples []metricsSample
+}
+
+func newMetricsSeries(size int) *metricsSeries {
+	if size < 2 {
+		size = 2
+	}
+	return &metricsSeries{size: size}
+}
+
+// add records a reading, deriving network rates from the previous sample.
+// now is used when the API does not timestamp the reading.
+func (s *metricsSeries) add(m *models.ServiceMetrics, now time.Time) metricsSample {
+	sample := metricsSample{
+		ServiceID: m.ServiceID,
+		Timestamp: m.Timestamp.Time,
+		CPU:       m.CPU,
+		Memory:    m.Memory,
+		Storage:   m.Storage,
+		Custom:    m.Custom,
+	}
+	if sample.Timestamp.IsZero() {
+		sample.Timestamp = now
+	}
+	if m.Network != nil {
+		sample.BytesIn = m.Network.BytesIn
+		sample.BytesOut = m.Network.BytesOut
+	}
+
+	if len(s.samples) > 0 {
+		prev := s.samples[len(s.samples)-1]
+		elapsed := sample.Timestamp.Sub(prev.Timestamp).Seconds()
+		if elapsed > 0 {
+			sample.BytesInPerSec = counterRate(prev.BytesIn, sample.BytesIn, elapsed)
+			sample.BytesOutPerSec = counterRate(prev.BytesOut, sample.BytesOut, elapsed)
+		}
+	}
+
+	s.samples = append(s.samples, sample)
+	if len(s.samples) > s.size {
+		s.samples = s.samples[len(s.samples)-s.size:]
+	}
+	return sample
+}
+
+// counterRate returns the per-second rate between two counter readings,
+// treating a decrease as a counter reset
+func counterRate(prev, cur int64, elapsed float64) *float64 {
+	delta := cur - prev
+	if delta < 0 {
+		delta = cur
+	}
+	rate := float64(delta) / elapsed
+	return &rate
+}
+
+// values extracts one series from the window
+func (s *metricsSeries) values(get func(metricsSample) (float64, bool)) []float64 {
+	var result []float64
+	for _, sample := range s.samples {
+		if v, ok := get(sample); ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// render draws the current window as a table of sparklines
+func (s *metricsSeries) render(w io.Writer, name string) {
+	if len(s.samples) == 0 {
+		return
+	}
+	latest := s.samples[len(s.samples)-1]
+
+	fmt.Fprintf(w, "Metrics for %s (%s)\n\n", name, latest.Timestamp.Local().Format("15:04:05"))
+
+	percent := func(label string, get func(metricsSample) float64) {
+		values := s.values(func(m metricsSample) (float64, bool) { return get(m), true })
+		fmt.Fprintf(w, "  %-10s %6.1f%%  %s\n", label, get(latest), outputpkg.Sparkline(values, 0, 100))
+	}
+	percent("CPU", func(m metricsSample) float64 { return m.CPU })
+	percent("Memory", func(m metricsSample) float64 { return m.Memory })
+	percent("Storage", func(m metricsSample) float64 { return m.Storage })
+
+	rate := func(label string, get func(metricsSample) *float64) {
+		values := s.values(func(m metricsSample) (float64, bool) {
+			if r := get(m); r != nil {
+				return *r, true
+			}
+			return 0, false
+		})
+		current := "-"
+		if r := get(latest); r != nil {
+			current = outputpkg.FormatBytes(*r) + "/s"
+		}
+		fmt.Fprintf(w, "  %-10s %12s  %s\n", label, current, outputpkg.Sparkline(values, 0, 0))
+	}
+	rate("Net in", func(m metricsSample) *float64 { return m.BytesInPerSec })
+	rate("Net out", func(m metricsSample) *float64 { return m.BytesOutPerSec })
+
+	writeCustomMetrics(w, latest.Custom)
+}
+
+// writeSnapshot prints a single reading as a table
+func writeSnapshot(w io.Writer, name string, sample metricsSample) {
+	fmt.Fprintf(w, "Metrics for %s (%s)\n\n", name, sample.Timestamp.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "  %-10s %6.1f%%  %s\n", "CPU", sample.CPU, outputpkg.PercentBar(sample.CPU, 20))
+	fmt.Fprintf(w, "  %-10s %6.1f%%  %s\n", "Memory", sample.Memory, outputpkg.PercentBar(sample.Memory, 20))
+	fmt.Fprintf(w, "  %-10s %6.1f%%  %s\n", "Storage", sample.Storage, outputpkg.PercentBar(sample.Storage, 20))
+	fmt.Fprintf(w, "  %-10s %s\n", "Net in", outputpkg.FormatBytes(float64(sample.BytesIn)))
+	fmt.Fprintf(w, "  %-10s %s\n", "Net out", outputpkg.FormatBytes(float64(sample.BytesOut)))
+	writeCustomMetrics(w, sample.Custom)
+}
+
+// writeCustomMetrics prints service-specific metrics sorted by name
+func writeCustomMetrics(w io.Writer, custom map[string]interface{}) {
+	if len(custom) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(custom))
+	for k := range custom {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(w)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %-24s %v\n", k+":", custom[k])
+	}
+}
+
+func runMetrics(cmd *cobra.Command, args []string) error {
+	serviceRef := args[0]
+
+	if metricsInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	jsonLines := metricsJSON || viper.GetString("defaults.output") == string(outputpkg.FormatJSON)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	service, err := ResolveService(ctx, client, serviceRef)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+
+	series := newMetricsSeries(metricsHistory)
+	encoder := json.NewEncoder(os.Stdout)
+	interactive := outputpkg.SupportsColor()
+
+	for {
+		metrics, err := client.GetServiceMetrics(ctx, service.ID)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			if !metricsWatch {
+				outputpkg.Error(fmt.Sprintf("Failed to get service metrics: %s", err))
+				return err
+			}
+			outputpkg.Warning(fmt.Sprintf("Failed to get service metrics: %s", err))
+		} else {
+			sample := series.add(metrics, time.Now())
+			switch {
+			case jsonLines:
+				if err := encoder.Encode(sample); err != nil {
+					return err
+				}
+			case !metricsWatch:
+				writeSnapshot(os.Stdout, service.Name, sample)
+			case interactive:
+				// Redraw in place
+				var b strings.Builder
+				series.render(&b, service.Name)
+				fmt.Print("\033[H\033[2J" + b.String())
+			default:
+				series.render(os.Stdout, service.Name)
+				fmt.Println()
+			}
+		}
+
+		if !metricsWatch {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(metricsInterval):
+		}
+	}
+}
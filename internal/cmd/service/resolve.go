@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// serviceGetter is the subset of the API client needed to resolve service references
+type serviceGetter interface {
+	ListServices(ctx context.Context) ([]models.Service, error)
+	GetService(ctx context.Context, serviceID string) (*models.Service, error)
+}
+
+// AmbiguousServiceError is returned when a reference matches more than one service
+type AmbiguousServiceError struct {
+	Ref        string
+	Candidates []models.Service
+}
+
+func (e *AmbiguousServiceError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "service reference %q is ambiguous, it matches:", e.Ref)
+	for _, svc := range e.Candidates {
+		fmt.Fprintf(&b, "\n  %s (%s)", svc.Name, svc.ID)
+	}
+	return b.String()
+}
+
+// ResolveService looks up a service by ID, exact name or unique name/ID prefix.
+// Exact ID matches win over exact name matches, which win over prefix matches.
+func ResolveService(ctx context.Context, client serviceGetter, ref string) (*models.Service, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("service ID or name is required")
+	}
+
+	services, listErr := client.ListServices(ctx)
+	if listErr != nil {
+		// Listing may be unavailable; fall back to treating the reference as an ID
+		svc, err := client.GetService(ctx, ref)
+		if errors.Is(err, api.ErrNotFound) {
+			// The reference may be a name, which only the listing can resolve
+			return nil, fmt.Errorf("failed to list services: %w", listErr)
+		}
+		return svc, err
+	}
+
+	var byName, byPrefix []models.Service
+	for _, svc := range services {
+		switch {
+		case svc.ID == ref:
+			found := svc
+			return &found, nil
+		case svc.Name == ref:
+			byName = append(byName, svc)
+		case strings.HasPrefix(svc.Name, ref) || strings.HasPrefix(svc.ID, ref):
+			byPrefix = append(byPrefix, svc)
+		}
+	}
+
+	for _, matches := range [][]models.Service{byName, byPrefix} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return &matches[0], nil
+		default:
+			sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+			return nil, &AmbiguousServiceError{Ref: ref, Candidates: matches}
+		}
+	}
+
+	// The service may exist without being part of the listing (e.g. another organization)
+	svc, err := client.GetService(ctx, ref)
+	if errors.Is(err, api.ErrNotFound) {
+		return nil, fmt.Errorf("%w: no service matches %q", api.ErrNotFound, ref)
+	}
+	return svc, err
+}
+
+// getServiceByRef resolves a service reference and fetches its full details
+func getServiceByRef(ctx context.Context, client serviceGetter, ref string) (*models.Service, error) {
+	svc, err := ResolveService(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetService(ctx, svc.ID)
+}
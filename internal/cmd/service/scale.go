@@ -3,31 +3,122 @@ package service
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/catalog"
 	"github.com/quickspin/quickspin-cli/internal/config"
 	"github.com/quickspin/quickspin-cli/internal/models"
 	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var (
+	scaleTier     string
+	scaleReplicas int
+	scaleYes      bool
+	scaleDryRun   bool
+	scaleWait     bool
+	scaleTimeout  time.Duration
+)
+
 // NewScaleCmd creates the service scale command
 func NewScaleCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "scale SERVICE_ID TIER",
-		Short: "Scale a service to a different tier",
-		Long:  "Scale a service to a different tier (starter, developer, basic, standard, pro, premium, enterprise)",
-		Args:  cobra.ExactArgs(2),
-		RunE:  runScale,
+		Use:   "scale SERVICE [TIER]",
+		Short: "Scale a service's tier or replica count",
+		Long: `Scale a service to a different tier (starter, developer, basic, standard, pro,
+premium, enterprise), change its number of replicas, or both. The tier may
+also be given as the second argument.
+
+Replicas are supported by Redis, RabbitMQ, MongoDB and Elasticsearch;
+PostgreSQL and MySQL run as a single node.
+
+The current and target configuration are shown side by side with their
+resources and monthly price before anything changes. Downgrades that leave
+less storage than the service currently uses are flagged.`,
+		Example: `  qspin service scale my-redis --tier pro
+  qspin service scale my-redis --replicas 3
+  qspin service scale my-redis --tier pro --replicas 3 --yes --wait
+  qspin service scale my-redis developer --dry-run`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runScale,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return tierNames(catalog.Cached(), ""), cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
 	}
 
+	cmd.Flags().StringVar(&scaleTier, "tier", "", "Target tier")
+	cmd.Flags().IntVar(&scaleReplicas, "replicas", 0, "Target number of replicas")
+	cmd.Flags().BoolVarP(&scaleYes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&scaleDryRun, "dry-run", false, "Show the preview without scaling")
+	cmd.Flags().BoolVar(&scaleWait, "wait", false, "Wait until the service is running on the new tier and replica count")
+	cmd.Flags().DurationVar(&scaleTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
+
+	_ = cmd.RegisterFlagCompletionFunc("tier", completeTiers)
+
 	return cmd
 }
 
+// buildScaleRequest validates the requested tier and replica count for a
+// service and builds the scale request. An empty tier or zero replicas leaves
+// that setting unchanged. changed is false when the service already matches.
+func buildScaleRequest(svc *models.Service, tier models.ServiceTier, replicas int, cat *catalog.Catalog) (req models.ServiceScaleRequest, changed bool, err error) {
+	if tier != "" {
+		if err := models.NewValidator(cat.ServiceTypes()).ValidateTier(svc.Type, tier); err != nil {
+			if !cat.Unverified(err) {
+				return req, false, err
+			}
+			outputpkg.Warning(catalog.UnverifiedWarning(err))
+		}
+		if tier != svc.Tier {
+			req.Tier = &tier
+		}
+	}
+	if replicas != 0 {
+		if err := models.ValidateReplicas(svc.Type, replicas); err != nil {
+			return req, false, err
+		}
+		if replicas != svc.ReplicaCount() {
+			req.Replicas = &replicas
+		}
+	}
+	return req, req.Tier != nil || req.Replicas != nil, nil
+}
+
 func runScale(cmd *cobra.Command, args []string) error {
-	serviceID := args[0]
-	tier := models.ServiceTier(args[1])
+	serviceRef := args[0]
+
+	tier := models.ServiceTier(scaleTier)
+	if len(args) == 2 {
+		if cmd.Flags().Changed("tier") {
+			return fmt.Errorf("give the tier either as an argument or with --tier, not both")
+		}
+		tier = models.ServiceTier(args[1])
+	}
+	replicas := 0
+	if cmd.Flags().Changed("replicas") {
+		replicas = scaleReplicas
+		if err := models.ValidateReplicas("", replicas); err != nil {
+			return err
+		}
+	}
+	if tier == "" && replicas == 0 {
+		return fmt.Errorf("nothing to scale (use --tier and/or --replicas)")
+	}
+	if tier != "" {
+		// Checked again against the service type once it is resolved
+		cat := catalog.Cached()
+		if err := models.NewValidator(cat.ServiceTypes()).ValidateTier("", tier); err != nil && !cat.Unverified(err) {
+			return err
+		}
+	}
 
 	ctx := context.Background()
 
@@ -40,12 +131,53 @@ func runScale(cmd *cobra.Command, args []string) error {
 	// Create API client
 	client := api.NewClient(cfg)
 
+	// Resolve service reference
+	target, err := ResolveService(ctx, client, serviceRef)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+
+	// Load the catalog and current usage for the preview. Both are optional:
+	// without them the preview shows what is known.
+	spinner := outputpkg.NewSpinner("Preparing scale preview...")
+	spinner.Start()
+	cat := catalog.Current(ctx, client)
+	metrics, _ := client.GetServiceMetrics(ctx, target.ID)
+	spinner.Stop()
+
+	req, changed, err := buildScaleRequest(target, tier, replicas, cat)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		outputpkg.Info(fmt.Sprintf("Service '%s' is already on the %s tier with %d replica(s)", target.Name, target.Tier, target.ReplicaCount()))
+		return nil
+	}
+
+	preview := newScalePreview(target, req, cat, metrics)
+	preview.Render(os.Stdout)
+	fmt.Println()
+	if warning := preview.StorageWarning(); warning != "" {
+		outputpkg.Warning(warning)
+		fmt.Println()
+	}
+
+	if scaleDryRun {
+		outputpkg.Info("Dry run: service was not scaled")
+		return nil
+	}
+	if !scaleYes && !outputpkg.Confirm("Scale this service?") {
+		outputpkg.Info("Scale cancelled")
+		return nil
+	}
+
 	// Show spinner
-	spinner := outputpkg.NewSpinner(fmt.Sprintf("Scaling service to %s tier...", tier))
+	spinner = outputpkg.NewSpinner(fmt.Sprintf("Scaling service to %s...", servicepkg.DescribeScale(req)))
 	spinner.Start()
 
 	// Scale service
-	service, err := client.ScaleService(ctx, serviceID, tier)
+	service, err := client.ScaleService(ctx, target.ID, req)
 	spinner.Stop()
 
 	if err != nil {
@@ -54,7 +186,15 @@ func runScale(cmd *cobra.Command, args []string) error {
 	}
 
 	// Success message
-	outputpkg.Success(fmt.Sprintf("Successfully scaled service '%s' to %s tier", service.Name, tier))
+	outputpkg.Success(fmt.Sprintf("Successfully scaled service '%s' to %s", service.Name, servicepkg.DescribeScale(req)))
+	if scaleWait {
+		service, err = waitWithProgress(ctx, client, service, servicepkg.ScaleCondition(req), scaleTimeout)
+		if err != nil {
+			outputpkg.Error(err.Error())
+			return err
+		}
+		outputpkg.Success(fmt.Sprintf("Service '%s' is running", service.Name))
+	}
 	fmt.Println()
 
 	formatType := outputpkg.Format(viper.GetString("defaults.output"))
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+)
+
+// scalePreview compares a service's current tier and replicas with the ones it is scaled to
+type scalePreview struct {
+	Service        *models.Service
+	Target         models.ServiceTier
+	TargetReplicas int
+
+	// CurrentInfo and TargetInfo come from the catalog and may be nil
+	CurrentInfo *models.TierInfo
+	TargetInfo  *models.TierInfo
+
+	// StorageUsed is the current storage usage in percent, if known
+	StorageUsed *float64
+}
+
+// newScalePreview builds a preview of a scale request from the catalog and the
+// latest metrics, either of which may be nil
+func newScalePreview(svc *models.Service, req models.ServiceScaleRequest, cat *catalog.Catalog, metrics *models.ServiceMetrics) scalePreview {
+	target := svc.Tier
+	if req.Tier != nil {
+		target = *req.Tier
+	}
+	replicas := svc.ReplicaCount()
+	if req.Replicas != nil {
+		replicas = *req.Replicas
+	}
+
+	preview := scalePreview{
+		Service:        svc,
+		Target:         target,
+		TargetReplicas: replicas,
+		CurrentInfo:    cat.Tier(svc.Type, svc.Tier),
+		TargetInfo:     cat.Tier(svc.Type, target),
+	}
+	if metrics != nil {
+		used := metrics.Storage
+		preview.StorageUsed = &used
+	}
+	return preview
+}
+
+// currentResources returns the current CPU, memory and storage, preferring
+// the catalog and falling back to what the service reports
+func (p scalePreview) currentResources() (cpu, memory, storage string) {
+	if p.CurrentInfo != nil {
+		return p.CurrentInfo.CPU, p.CurrentInfo.Memory, p.CurrentInfo.Storage
+	}
+	if p.Service.Resources != nil {
+		return p.Service.Resources.CPU, p.Service.Resources.Memory, p.Service.Resources.Storage
+	}
+	return "", "", ""
+}
+
+// CostDelta returns the change in monthly price across all replicas, if both prices are known
+func (p scalePreview) CostDelta() (float64, bool) {
+	if p.CurrentInfo == nil || p.TargetInfo == nil {
+		return 0, false
+	}
+	current := p.CurrentInfo.Price * float64(p.Service.ReplicaCount())
+	target := p.TargetInfo.Price * float64(p.TargetReplicas)
+	return target - current, true
+}
+
+// StorageWarning returns a warning when the target tier has less storage than
+// the service currently uses, or when that cannot be checked for a tier with
+// less storage. It returns "" when there is nothing to warn about.
+func (p scalePreview) StorageWarning() string {
+	if p.TargetInfo == nil {
+		return ""
+	}
+	_, _, current := p.currentResources()
+	currentBytes, okCurrent := parseByteQuantity(current)
+	targetBytes, okTarget := parseByteQuantity(p.TargetInfo.Storage)
+	if !okCurrent || !okTarget || targetBytes >= currentBytes {
+		return ""
+	}
+
+	if p.StorageUsed == nil {
+		return fmt.Sprintf("Storage shrinks from %s to %s and current usage could not be checked", current, p.TargetInfo.Storage)
+	}
+
+	usedBytes := currentBytes * *p.StorageUsed / 100
+	if usedBytes <= targetBytes {
+		return ""
+	}
+	return fmt.Sprintf("Service uses %s of storage but the %s tier only provides %s; data may not fit",
+		outputpkg.FormatBytes(usedBytes), p.Target, p.TargetInfo.Storage)
+}
+
+// Render writes the side-by-side comparison
+func (p scalePreview) Render(w io.Writer) {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	price := func(info *models.TierInfo, replicas int) string {
+		if info == nil {
+			return "-"
+		}
+		if replicas > 1 {
+			return fmt.Sprintf("$%.2f/mo (%d x $%.2f)", info.Price*float64(replicas), replicas, info.Price)
+		}
+		return fmt.Sprintf("$%.2f/mo", info.Price)
+	}
+
+	cpu, memory, storage := p.currentResources()
+	var targetCPU, targetMemory, targetStorage string
+	if p.TargetInfo != nil {
+		targetCPU, targetMemory, targetStorage = p.TargetInfo.CPU, p.TargetInfo.Memory, p.TargetInfo.Storage
+	}
+	if p.StorageUsed != nil && storage != "" {
+		storage = fmt.Sprintf("%s (%.0f%% used)", storage, *p.StorageUsed)
+	}
+
+	fmt.Fprintf(w, "Scaling service '%s' (%s)\n\n", p.Service.Name, p.Service.ID)
+	fmt.Fprintf(w, "  %-10s %-26s %s\n", "", "CURRENT ("+string(p.Service.Tier)+")", "TARGET ("+string(p.Target)+")")
+	fmt.Fprintf(w, "  %-10s %-26s %s\n", "CPU", orDash(cpu), orDash(targetCPU))
+	fmt.Fprintf(w, "  %-10s %-26s %s\n", "Memory", orDash(memory), orDash(targetMemory))
+	fmt.Fprintf(w, "  %-10s %-26s %s\n", "Storage", orDash(storage), orDash(targetStorage))
+	fmt.Fprintf(w, "  %-10s %-26d %d\n", "Replicas", p.Service.ReplicaCount(), p.TargetReplicas)
+	fmt.Fprintf(w, "  %-10s %-26s %s\n", "Price", price(p.CurrentInfo, p.Service.ReplicaCount()), price(p.TargetInfo, p.TargetReplicas))
+	fmt.Fprintln(w)
+
+	if delta, ok := p.CostDelta(); ok {
+		sign := "+"
+		if delta < 0 {
+			sign, delta = "-", -delta
+		}
+		fmt.Fprintf(w, "  Cost change: %s$%.2f/mo\n", sign, delta)
+	} else {
+		fmt.Fprintln(w, "  Cost change: unknown (tier not in catalog)")
+	}
+}
+
+// byteUnits maps storage quantity suffixes to their size in bytes
+var byteUnits = map[string]float64{
+	"":   1,
+	"B":  1,
+	"K":  1e3,
+	"KB": 1e3,
+	"M":  1e6,
+	"MB": 1e6,
+	"G":  1e9,
+	"GB": 1e9,
+	"T":  1e12,
+	"TB": 1e12,
+	"KI": 1 << 10,
+	"MI": 1 << 20,
+	"GI": 1 << 30,
+	"TI": 1 << 40,
+}
+
+// parseByteQuantity parses storage sizes such as 10Gi, 512Mi or 5GB
+func parseByteQuantity(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	unit, ok := byteUnits[strings.ToUpper(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, false
+	}
+	return n * unit, true
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// selectorOp is a label selector operator
+type selectorOp string
+
+const (
+	opEquals       selectorOp = "="
+	opNotEquals    selectorOp = "!="
+	opIn           selectorOp = "in"
+	opNotIn        selectorOp = "notin"
+	opExists       selectorOp = "exists"
+	opDoesNotExist selectorOp = "!"
+)
+
+// setClause matches the start of a set-based clause such as "env in (a,b)"
+var setClause = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(`)
+
+// requirement is a single clause of a label selector
+type requirement struct {
+	key    string
+	op     selectorOp
+	values []string
+}
+
+// Selector is a kubectl-style label selector such as "team=backend,env!=prod".
+// Supported clauses: key=value, key==value, key!=value, key, !key,
+// key in (a,b) and key notin (a,b). All clauses must match.
+type Selector struct {
+	requirements []requirement
+	raw          string
+}
+
+// ParseSelector parses a label selector expression
+func ParseSelector(expr string) (Selector, error) {
+	sel := Selector{raw: strings.TrimSpace(expr)}
+	if sel.raw == "" {
+		return sel, nil
+	}
+
+	for _, clause := range splitSelector(sel.raw) {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			return Selector{}, fmt.Errorf("invalid selector %q: empty clause", expr)
+		}
+
+		req, err := parseRequirement(clause)
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid selector %q: %w", expr, err)
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+
+	return sel, nil
+}
+
+// splitSelector splits a selector on commas that are not inside parentheses
+func splitSelector(expr string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range expr {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+// parseRequirement parses a single selector clause
+func parseRequirement(clause string) (requirement, error) {
+	if strings.HasPrefix(clause, "!") {
+		key := strings.TrimSpace(clause[1:])
+		if key == "" {
+			return requirement{}, fmt.Errorf("missing key in %q", clause)
+		}
+		return requirement{key: key, op: opDoesNotExist}, nil
+	}
+
+	// Set-based clauses: key in (a,b) / key notin (a,b). Parentheses
+	// elsewhere, as in team=a(b), are part of a value.
+	if m := setClause.FindStringSubmatch(clause); m != nil {
+		if !strings.HasSuffix(clause, ")") {
+			return requirement{}, fmt.Errorf("unterminated value list in %q", clause)
+		}
+		var values []string
+		for _, v := range strings.Split(clause[len(m[0]):len(clause)-1], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+		return requirement{key: m[1], op: selectorOp(m[2]), values: values}, nil
+	}
+
+	for _, op := range []string{"!=", "==", "="} {
+		if idx := strings.Index(clause, op); idx >= 0 {
+			key := strings.TrimSpace(clause[:idx])
+			value := strings.TrimSpace(clause[idx+len(op):])
+			if key == "" {
+				return requirement{}, fmt.Errorf("missing key in %q", clause)
+			}
+			if op == "!=" {
+				return requirement{key: key, op: opNotEquals, values: []string{value}}, nil
+			}
+			return requirement{key: key, op: opEquals, values: []string{value}}, nil
+		}
+	}
+
+	if strings.ContainsAny(clause, " \t") {
+		return requirement{}, fmt.Errorf("invalid clause %q", clause)
+	}
+	return requirement{key: clause, op: opExists}, nil
+}
+
+// Empty reports whether the selector has no requirements and so matches everything
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// String returns the selector expression as given
+func (s Selector) String() string {
+	return s.raw
+}
+
+// Matches reports whether the labels satisfy every requirement of the selector
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s.requirements {
+		value, ok := labels[req.key]
+		switch req.op {
+		case opExists:
+			if !ok {
+				return false
+			}
+		case opDoesNotExist:
+			if ok {
+				return false
+			}
+		case opEquals:
+			if !ok || value != req.values[0] {
+				return false
+			}
+		case opNotEquals:
+			// Like kubectl, a missing key satisfies key!=value
+			if ok && value == req.values[0] {
+				return false
+			}
+		case opIn:
+			if !ok || !containsString(req.values, value) {
+				return false
+			}
+		case opNotIn:
+			if ok && containsString(req.values, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
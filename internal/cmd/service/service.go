@@ -20,6 +20,13 @@ func NewServiceCmd() *cobra.Command {
 	cmd.AddCommand(NewDescribeCmd())
 	cmd.AddCommand(NewScaleCmd())
 	cmd.AddCommand(NewLogsCmd())
+	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewConnectCmd())
+	cmd.AddCommand(NewWaitCmd())
+	cmd.AddCommand(NewMetricsCmd())
+	cmd.AddCommand(NewStopCmd())
+	cmd.AddCommand(NewStartCmd())
+	cmd.AddCommand(NewRestartCmd())
 
 	return cmd
 }
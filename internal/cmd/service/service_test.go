@@ -3,9 +3,16 @@ package service
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/catalog"
+	"github.com/quickspin/quickspin-cli/internal/config"
 	"github.com/quickspin/quickspin-cli/internal/models"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -46,8 +53,8 @@ func (m *MockAPIClient) DeleteService(ctx context.Context, serviceID string) err
 	return args.Error(0)
 }
 
-func (m *MockAPIClient) ScaleService(ctx context.Context, serviceID string, tier models.ServiceTier) (*models.Service, error) {
-	args := m.Called(ctx, serviceID, tier)
+func (m *MockAPIClient) ScaleService(ctx context.Context, serviceID string, req models.ServiceScaleRequest) (*models.Service, error) {
+	args := m.Called(ctx, serviceID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -84,7 +91,7 @@ func TestNewServiceCmd(t *testing.T) {
 func TestServiceSubcommands(t *testing.T) {
 	cmd := NewServiceCmd()
 
-	expectedSubcommands := []string{"list", "create", "delete", "describe", "scale", "logs"}
+	expectedSubcommands := []string{"list", "create", "delete", "describe", "scale", "logs", "update", "connect", "wait", "metrics", "stop", "start", "restart"}
 	actualSubcommands := make(map[string]bool)
 
 	for _, subCmd := range cmd.Commands() {
@@ -203,6 +210,21 @@ func TestCreateCommand(t *testing.T) {
 			assert.NotNil(t, cmd.Flags().Lookup("type"))
 			assert.NotNil(t, cmd.Flags().Lookup("tier"))
 			assert.NotNil(t, cmd.Flags().Lookup("region"))
+
+			req, err := buildCreateRequest(createOptions{
+				Name:   tt.flagName,
+				Type:   tt.flagType,
+				Tier:   &tt.flagTier,
+				Region: tt.flagRegion,
+			}, nil, nil)
+			if err == nil {
+				err = validateCreateRequest(req, nil)
+			}
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -342,6 +364,11 @@ func TestScaleCommand(t *testing.T) {
 			// Verify tier flag exists
 			tierFlag := cmd.Flags().Lookup("tier")
 			assert.NotNil(t, tierFlag)
+
+			assert.NotNil(t, cmd.Flags().Lookup("replicas"))
+
+			err := models.NewValidator(nil).ValidateTier("", models.ServiceTier(tt.tier))
+			assert.Equal(t, tt.tier == "invalid-tier", err != nil)
 		})
 	}
 }
@@ -412,6 +439,13 @@ func TestServiceCommandHelp(t *testing.T) {
 		{name: "Describe command help", cmd: NewDescribeCmd()},
 		{name: "Scale command help", cmd: NewScaleCmd()},
 		{name: "Logs command help", cmd: NewLogsCmd()},
+		{name: "Update command help", cmd: NewUpdateCmd()},
+		{name: "Connect command help", cmd: NewConnectCmd()},
+		{name: "Wait command help", cmd: NewWaitCmd()},
+		{name: "Metrics command help", cmd: NewMetricsCmd()},
+		{name: "Stop command help", cmd: NewStopCmd()},
+		{name: "Start command help", cmd: NewStartCmd()},
+		{name: "Restart command help", cmd: NewRestartCmd()},
 	}
 
 	for _, tt := range tests {
@@ -474,3 +508,577 @@ func TestServiceStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestLogCursorDeduplicates(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(offset time.Duration, msg string) models.ServiceLogEntry {
+		return models.ServiceLogEntry{Timestamp: models.Time{Time: base.Add(offset)}, Level: "info", Message: msg}
+	}
+
+	cursor := newLogCursor()
+	assert.True(t, cursor.accept(entry(0, "first")))
+	assert.True(t, cursor.accept(entry(time.Second, "second")))
+	assert.True(t, cursor.accept(entry(time.Second, "second, same timestamp")))
+
+	// A subsequent poll returns overlapping entries
+	assert.False(t, cursor.accept(entry(0, "first")))
+	assert.False(t, cursor.accept(entry(time.Second, "second")))
+	assert.False(t, cursor.accept(entry(time.Second, "second, same timestamp")))
+	assert.True(t, cursor.accept(entry(2*time.Second, "third")))
+
+	// Entries without a timestamp are remembered after the cursor advances
+	untimed := models.ServiceLogEntry{Level: "info", Message: "no timestamp"}
+	assert.True(t, cursor.accept(untimed))
+	assert.True(t, cursor.accept(entry(3*time.Second, "fourth")))
+	assert.False(t, cursor.accept(untimed))
+}
+
+func TestLogFilter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	filter, err := newLogFilter("10m", "warn", "conn(ection)?", now)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		entry models.ServiceLogEntry
+		want  bool
+	}{
+		{"Grep is case-sensitive", models.ServiceLogEntry{Timestamp: models.Time{Time: now}, Level: "error", Message: "Connection failed"}, false},
+		{"Matching warning", models.ServiceLogEntry{Timestamp: models.Time{Time: now}, Level: "warn", Message: "connection slow"}, true},
+		{"Below level", models.ServiceLogEntry{Timestamp: models.Time{Time: now}, Level: "info", Message: "connection ok"}, false},
+		{"Too old", models.ServiceLogEntry{Timestamp: models.Time{Time: now.Add(-time.Hour)}, Level: "error", Message: "connection lost"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filter.match(tt.entry))
+		})
+	}
+
+	_, err = newLogFilter("yesterday", "", "", now)
+	assert.Error(t, err)
+	_, err = newLogFilter("", "loud", "", now)
+	assert.Error(t, err)
+}
+
+func TestResolveService(t *testing.T) {
+	services := []models.Service{
+		{ID: "svc-1a2b", Name: "redis-cache"},
+		{ID: "svc-3c4d", Name: "redis-sessions"},
+		{ID: "svc-5e6f", Name: "postgres-db"},
+	}
+
+	tests := []struct {
+		name      string
+		ref       string
+		expectID  string
+		ambiguous bool
+		wantErr   bool
+	}{
+		{name: "Exact ID", ref: "svc-3c4d", expectID: "svc-3c4d"},
+		{name: "Exact name", ref: "postgres-db", expectID: "svc-5e6f"},
+		{name: "Unique prefix", ref: "redis-c", expectID: "svc-1a2b"},
+		{name: "Ambiguous prefix", ref: "redis", ambiguous: true, wantErr: true},
+		{name: "Not found", ref: "mongo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockAPIClient)
+			client.On("ListServices", mock.Anything).Return(services, nil)
+			client.On("GetService", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: gone", api.ErrNotFound)).Maybe()
+
+			svc, err := ResolveService(context.Background(), client, tt.ref)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.ambiguous {
+					var ambiguous *AmbiguousServiceError
+					require.ErrorAs(t, err, &ambiguous)
+					assert.Len(t, ambiguous.Candidates, 2)
+					assert.Contains(t, err.Error(), "redis-sessions (svc-3c4d)")
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectID, svc.ID)
+		})
+	}
+}
+
+func TestResolveServiceFallsBackToGet(t *testing.T) {
+	client := new(MockAPIClient)
+	client.On("ListServices", mock.Anything).Return(nil, assert.AnError)
+	client.On("GetService", mock.Anything, "svc-9").Return(&models.Service{ID: "svc-9", Name: "hidden"}, nil)
+
+	svc, err := ResolveService(context.Background(), client, "svc-9")
+	require.NoError(t, err)
+	assert.Equal(t, "hidden", svc.Name)
+	client.AssertExpectations(t)
+}
+
+func TestResolveServiceKeepsErrors(t *testing.T) {
+	notFound := fmt.Errorf("%w: gone", api.ErrNotFound)
+
+	// A failed listing is reported rather than a missing service
+	client := new(MockAPIClient)
+	client.On("ListServices", mock.Anything).Return(nil, api.ErrForbidden)
+	client.On("GetService", mock.Anything, "cache").Return(nil, notFound)
+	_, err := ResolveService(context.Background(), client, "cache")
+	assert.ErrorIs(t, err, api.ErrForbidden)
+	assert.NotErrorIs(t, err, api.ErrNotFound)
+
+	// Errors other than not found are returned as they are
+	client = new(MockAPIClient)
+	client.On("ListServices", mock.Anything).Return([]models.Service{}, nil)
+	client.On("GetService", mock.Anything, "cache").Return(nil, fmt.Errorf("%w: token expired", api.ErrUnauthorized))
+	_, err = ResolveService(context.Background(), client, "cache")
+	assert.ErrorIs(t, err, api.ErrUnauthorized)
+
+	client = new(MockAPIClient)
+	client.On("ListServices", mock.Anything).Return([]models.Service{}, nil)
+	client.On("GetService", mock.Anything, "cache").Return(nil, notFound)
+	_, err = ResolveService(context.Background(), client, "cache")
+	assert.EqualError(t, err, `resource not found: no service matches "cache"`)
+}
+
+func TestParseSelector(t *testing.T) {
+	labels := map[string]string{"team": "backend", "env": "dev"}
+
+	tests := []struct {
+		expr    string
+		matches bool
+		wantErr bool
+	}{
+		{expr: "", matches: true},
+		{expr: "team=backend", matches: true},
+		{expr: "team==backend,env!=prod", matches: true},
+		{expr: "env!=dev", matches: false},
+		{expr: "owner!=alice", matches: true},
+		{expr: "env in (dev, staging)", matches: true},
+		{expr: "env notin (dev,staging),team", matches: false},
+		{expr: "team,!owner", matches: true},
+		{expr: "owner", matches: false},
+		{expr: "team=backend,", wantErr: true},
+		{expr: "env in (dev", wantErr: true},
+		{expr: "env is dev", wantErr: true},
+		{expr: "team=backend(eu)", matches: false},
+		{expr: "team!=a(b),env in(dev)", matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			sel, err := ParseSelector(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, sel.Matches(labels))
+		})
+	}
+
+	sel, err := ParseSelector("team=a(b)")
+	require.NoError(t, err)
+	assert.True(t, sel.Matches(map[string]string{"team": "a(b)"}))
+}
+
+func TestServiceFilterAndSort(t *testing.T) {
+	services := []models.Service{
+		{Name: "b-cache", Type: models.ServiceTypeRedis, Status: models.ServiceStatusRunning, Tier: models.ServiceTierPro, Labels: map[string]string{"env": "prod"}},
+		{Name: "a-cache", Type: models.ServiceTypeRedis, Status: models.ServiceStatusRunning, Tier: models.ServiceTierStarter, Labels: map[string]string{"env": "dev"}},
+		{Name: "db", Type: models.ServiceTypePostgreSQL, Status: models.ServiceStatusStopped, Tier: models.ServiceTierBasic},
+	}
+
+	sel, err := ParseSelector("env!=prod")
+	require.NoError(t, err)
+
+	filtered := serviceFilter{Types: []string{"redis"}, Statuses: []string{"RUNNING"}, Selector: sel}.Apply(services)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "a-cache", filtered[0].Name)
+
+	require.NoError(t, sortServices(services, "tier"))
+	assert.Equal(t, []string{"a-cache", "db", "b-cache"}, []string{services[0].Name, services[1].Name, services[2].Name})
+
+	require.NoError(t, sortServices(services, "name"))
+	assert.Equal(t, "a-cache", services[0].Name)
+
+	assert.Error(t, sortServices(services, "size"))
+	assert.NoError(t, validSortKey("created"))
+	assert.Error(t, validSortKey("size"))
+}
+
+func TestLifecycleTargets(t *testing.T) {
+	services := []models.Service{
+		{Name: "dev-cache", Status: models.ServiceStatusRunning, Labels: map[string]string{"env": "dev"}},
+		{Name: "dev-db", Status: models.ServiceStatusStopped, Labels: map[string]string{"env": "dev"}},
+		{Name: "prod-db", Status: models.ServiceStatusRunning, Labels: map[string]string{"env": "prod"}},
+	}
+	selector, err := ParseSelector("env=dev")
+	require.NoError(t, err)
+
+	names := func(svcs []models.Service) []string {
+		var result []string
+		for _, svc := range svcs {
+			result = append(result, svc.Name)
+		}
+		return result
+	}
+
+	stop := &lifecycleAction{noop: models.ServiceStatusStopped}
+	assert.Equal(t, []string{"dev-cache"}, names(stop.targets(services, selector)))
+
+	start := &lifecycleAction{noop: models.ServiceStatusRunning}
+	assert.Equal(t, []string{"dev-db"}, names(start.targets(services, selector)))
+
+	restart := &lifecycleAction{}
+	assert.Equal(t, []string{"dev-cache", "dev-db"}, names(restart.targets(services, selector)))
+}
+
+func TestLifecycleRequiresTarget(t *testing.T) {
+	cmd := NewStopCmd()
+	_, err := executeCommand(cmd)
+	assert.Error(t, err)
+
+	cmd = NewRestartCmd()
+	_, err = executeCommand(cmd, "svc-1", "--selector", "env=dev")
+	assert.Error(t, err)
+
+	cmd = NewStopCmd()
+	_, err = executeCommand(cmd, "--selector", " ", "--force")
+	assert.EqualError(t, err, `label selector " " is empty`)
+}
+
+func TestBuildUpdate(t *testing.T) {
+	service := &models.Service{
+		ID:     "svc-1",
+		Name:   "cache",
+		Config: map[string]interface{}{"maxmemory": "256mb", "persistence": "enabled", "databases": float64(16)},
+		Labels: map[string]string{"team": "backend", "owner": "alice"},
+	}
+
+	newName := "cache-primary"
+	req, changes, err := buildUpdate(service, updateOptions{
+		Name:   &newName,
+		Set:    []string{"maxmemory=512mb", "databases=16", "timeout=300"},
+		Unset:  []string{"persistence", "missing"},
+		Labels: []string{"team=backend", "env=dev", "owner-"},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, req.Name)
+	assert.Equal(t, "cache-primary", *req.Name)
+	assert.Equal(t, map[string]interface{}{"maxmemory": "512mb", "timeout": int64(300), "persistence": nil}, req.Config)
+	require.Contains(t, req.Labels, "owner")
+	assert.Nil(t, req.Labels["owner"])
+	assert.Equal(t, "dev", *req.Labels["env"])
+	assert.NotContains(t, req.Labels, "team")
+
+	var lines []string
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+	assert.Equal(t, []string{
+		`~ name: "cache" -> "cache-primary"`,
+		`~ config.maxmemory: "256mb" -> "512mb"`,
+		`- config.persistence: "enabled"`,
+		`+ config.timeout: 300`,
+		`+ labels.env: "dev"`,
+		`- labels.owner: "alice"`,
+	}, lines)
+}
+
+func TestBuildUpdateConflicts(t *testing.T) {
+	service := &models.Service{Config: map[string]interface{}{"a": 1}}
+
+	_, _, err := buildUpdate(service, updateOptions{Set: []string{"a=2"}, Unset: []string{"a"}})
+	assert.Error(t, err)
+
+	_, _, err = buildUpdate(service, updateOptions{Labels: []string{"novalue"}})
+	assert.Error(t, err)
+}
+
+func TestParseConfigValue(t *testing.T) {
+	assert.Equal(t, int64(42), parseConfigValue("42"))
+	assert.Equal(t, 0.5, parseConfigValue("0.5"))
+	assert.Equal(t, true, parseConfigValue("true"))
+	assert.Equal(t, "42", parseConfigValue(`"42"`))
+	assert.Equal(t, "allkeys-lru", parseConfigValue("allkeys-lru"))
+	assert.Equal(t, "inf", parseConfigValue("inf"))
+	assert.Equal(t, []interface{}{"uuid-ossp", "pg_trgm"}, parseConfigValue("[uuid-ossp, pg_trgm]"))
+	assert.Equal(t, []interface{}{int64(1), true, "x"}, parseConfigValue("[1,true,x]"))
+	assert.Equal(t, []interface{}{}, parseConfigValue("[]"))
+}
+
+func TestBuildCreateRequest(t *testing.T) {
+	pro := "pro"
+	preset := &config.Preset{
+		Name:   "cache",
+		Type:   "redis",
+		Tier:   "basic",
+		Config: map[string]interface{}{"maxmemory": "128mb", "persistence": "enabled"},
+		Labels: map[string]string{"team": "platform"},
+	}
+	tmpl := &models.ServiceTemplate{
+		Name:   "cache-primary",
+		Type:   models.ServiceTypeRedis,
+		Tier:   models.ServiceTier("standard"),
+		Region: "eu-west-1",
+		Config: map[string]interface{}{"maxmemory": "256mb"},
+		Labels: map[string]string{"env": "dev"},
+	}
+
+	t.Run("flags override file and preset", func(t *testing.T) {
+		req, err := buildCreateRequest(createOptions{
+			Tier:   &pro,
+			Config: []string{"maxmemory=512mb", "databases=4"},
+			Labels: []string{"team=backend"},
+		}, preset, tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, "cache-primary", req.Name)
+		assert.Equal(t, models.ServiceTypeRedis, req.Type)
+		assert.Equal(t, models.ServiceTier("pro"), req.Tier)
+		assert.Equal(t, "eu-west-1", req.Region)
+		assert.Equal(t, map[string]interface{}{"maxmemory": "512mb", "persistence": "enabled", "databases": int64(4)}, req.Config)
+		assert.Equal(t, map[string]string{"team": "backend", "env": "dev"}, req.Labels)
+	})
+
+	t.Run("preset supplies type and tier", func(t *testing.T) {
+		req, err := buildCreateRequest(createOptions{Name: "cache"}, preset, nil)
+		require.NoError(t, err)
+		assert.Equal(t, models.ServiceTypeRedis, req.Type)
+		assert.Equal(t, models.ServiceTier("basic"), req.Tier)
+	})
+
+	t.Run("default tier", func(t *testing.T) {
+		req, err := buildCreateRequest(createOptions{Name: "db", Type: "postgresql"}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, defaultCreateTier, req.Tier)
+		assert.Nil(t, req.Config)
+		assert.Nil(t, req.Labels)
+	})
+
+	t.Run("preset type mismatch", func(t *testing.T) {
+		_, err := buildCreateRequest(createOptions{Name: "db", Type: "postgresql"}, preset, nil)
+		assert.ErrorContains(t, err, "preset")
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := buildCreateRequest(createOptions{Type: "redis"}, nil, nil)
+		assert.ErrorContains(t, err, "name is required")
+	})
+
+	t.Run("invalid label", func(t *testing.T) {
+		_, err := buildCreateRequest(createOptions{Name: "c", Type: "redis", Labels: []string{"team"}}, nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseWaitCondition(t *testing.T) {
+	cond, err := parseWaitCondition("status=running")
+	require.NoError(t, err)
+	assert.Equal(t, models.ServiceStatusRunning, cond.Status)
+
+	cond, err = parseWaitCondition("delete")
+	require.NoError(t, err)
+	assert.True(t, cond.Deleted)
+
+	_, err = parseWaitCondition("status=ready")
+	assert.Error(t, err)
+	_, err = parseWaitCondition("running")
+	assert.Error(t, err)
+}
+
+func TestMetricsSeries(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	reading := func(offset time.Duration, in, out int64) *models.ServiceMetrics {
+		return &models.ServiceMetrics{
+			Timestamp: models.Time{Time: base.Add(offset)},
+			CPU:       25,
+			Network:   &models.NetworkMetrics{BytesIn: in, BytesOut: out},
+		}
+	}
+
+	series := newMetricsSeries(3)
+
+	first := series.add(reading(0, 1000, 500), base)
+	assert.Nil(t, first.BytesInPerSec)
+
+	second := series.add(reading(10*time.Second, 3000, 1500), base)
+	require.NotNil(t, second.BytesInPerSec)
+	assert.Equal(t, 200.0, *second.BytesInPerSec)
+	assert.Equal(t, 100.0, *second.BytesOutPerSec)
+
+	// Counter reset: the new reading counts from zero
+	third := series.add(reading(20*time.Second, 500, 1500), base)
+	assert.Equal(t, 50.0, *third.BytesInPerSec)
+	assert.Equal(t, 0.0, *third.BytesOutPerSec)
+
+	series.add(reading(30*time.Second, 600, 1600), base)
+	assert.Len(t, series.samples, 3)
+
+	var b strings.Builder
+	series.render(&b, "cache")
+	assert.Contains(t, b.String(), "Metrics for cache")
+	assert.Contains(t, b.String(), "Net in")
+}
+
+func TestCompletionNames(t *testing.T) {
+	assert.Len(t, serviceTypeNames(nil), len(models.ServiceTypes))
+	assert.Len(t, tierNames(nil, models.ServiceTypeRedis), len(models.ServiceTiers))
+
+	cat := &catalog.Catalog{Types: []models.ServiceTypeInfo{
+		{Type: models.ServiceTypeRedis, Tiers: []models.TierInfo{{Tier: models.ServiceTierStarter}, {Tier: models.ServiceTierPro}}},
+	}}
+	assert.Equal(t, []string{"redis"}, serviceTypeNames(cat))
+	assert.Equal(t, []string{"starter", "pro"}, tierNames(cat, models.ServiceTypeRedis))
+	assert.Len(t, tierNames(cat, models.ServiceTypeMySQL), len(models.ServiceTiers))
+}
+
+func TestParseByteQuantity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"10Gi", 10 << 30, true},
+		{"512Mi", 512 << 20, true},
+		{"5GB", 5e9, true},
+		{"1.5 G", 1.5e9, true},
+		{"2048", 2048, true},
+		{"", 0, false},
+		{"lots", 0, false},
+		{"10Xi", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseByteQuantity(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
+
+func scaleToTier(tier models.ServiceTier) models.ServiceScaleRequest {
+	return models.ServiceScaleRequest{Tier: &tier}
+}
+
+func TestScalePreview(t *testing.T) {
+	cat := &catalog.Catalog{Types: []models.ServiceTypeInfo{{
+		Type: models.ServiceTypeRedis,
+		Tiers: []models.TierInfo{
+			{Tier: models.ServiceTierStarter, CPU: "250m", Memory: "512Mi", Storage: "1Gi", Price: 5},
+			{Tier: models.ServiceTierPro, CPU: "1000m", Memory: "2Gi", Storage: "10Gi", Price: 80},
+		},
+	}}}
+	svc := &models.Service{ID: "svc-1", Name: "cache", Type: models.ServiceTypeRedis, Tier: models.ServiceTierPro}
+
+	t.Run("upgrade", func(t *testing.T) {
+		svc := &models.Service{ID: "svc-1", Name: "cache", Type: models.ServiceTypeRedis, Tier: models.ServiceTierStarter}
+		preview := newScalePreview(svc, scaleToTier(models.ServiceTierPro), cat, nil)
+		delta, ok := preview.CostDelta()
+		require.True(t, ok)
+		assert.Equal(t, 75.0, delta)
+		assert.Empty(t, preview.StorageWarning())
+
+		var buf bytes.Buffer
+		preview.Render(&buf)
+		assert.Contains(t, buf.String(), "$5.00/mo")
+		assert.Contains(t, buf.String(), "Cost change: +$75.00/mo")
+	})
+
+	t.Run("downgrade below usage", func(t *testing.T) {
+		preview := newScalePreview(svc, scaleToTier(models.ServiceTierStarter), cat, &models.ServiceMetrics{Storage: 30})
+		assert.Contains(t, preview.StorageWarning(), "3.0 GiB")
+
+		var buf bytes.Buffer
+		preview.Render(&buf)
+		assert.Contains(t, buf.String(), "Cost change: -$75.00/mo")
+		assert.Contains(t, buf.String(), "10Gi (30% used)")
+	})
+
+	t.Run("downgrade that fits", func(t *testing.T) {
+		preview := newScalePreview(svc, scaleToTier(models.ServiceTierStarter), cat, &models.ServiceMetrics{Storage: 5})
+		assert.Empty(t, preview.StorageWarning())
+	})
+
+	t.Run("downgrade without metrics", func(t *testing.T) {
+		preview := newScalePreview(svc, scaleToTier(models.ServiceTierStarter), cat, nil)
+		assert.Contains(t, preview.StorageWarning(), "could not be checked")
+	})
+
+	t.Run("replicas", func(t *testing.T) {
+		replicas := 3
+		preview := newScalePreview(svc, models.ServiceScaleRequest{Replicas: &replicas}, cat, nil)
+		assert.Equal(t, models.ServiceTierPro, preview.Target)
+		delta, ok := preview.CostDelta()
+		require.True(t, ok)
+		assert.Equal(t, 160.0, delta)
+
+		var buf bytes.Buffer
+		preview.Render(&buf)
+		assert.Contains(t, buf.String(), "$240.00/mo (3 x $80.00)")
+	})
+
+	t.Run("no catalog", func(t *testing.T) {
+		svc := &models.Service{Tier: models.ServiceTierPro, Resources: &models.ServiceResources{CPU: "1", Memory: "2Gi", Storage: "10Gi"}}
+		preview := newScalePreview(svc, scaleToTier(models.ServiceTierStarter), nil, nil)
+		_, ok := preview.CostDelta()
+		assert.False(t, ok)
+
+		var buf bytes.Buffer
+		preview.Render(&buf)
+		assert.Contains(t, buf.String(), "2Gi")
+		assert.Contains(t, buf.String(), "unknown")
+	})
+}
+
+func TestBuildScaleRequest(t *testing.T) {
+	redis := &models.Service{Type: models.ServiceTypeRedis, Tier: models.ServiceTierDeveloper, Replicas: 1}
+	postgres := &models.Service{Type: models.ServiceTypePostgreSQL, Tier: models.ServiceTierDeveloper}
+
+	req, changed, err := buildScaleRequest(redis, models.ServiceTierPro, 3, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NotNil(t, req.Tier)
+	require.NotNil(t, req.Replicas)
+	assert.Equal(t, models.ServiceTierPro, *req.Tier)
+	assert.Equal(t, 3, *req.Replicas)
+	assert.Equal(t, "pro tier with 3 replica(s)", servicepkg.DescribeScale(req))
+
+	req, changed, err = buildScaleRequest(redis, "", 2, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Nil(t, req.Tier)
+	assert.Equal(t, "2 replica(s)", servicepkg.DescribeScale(req))
+
+	_, changed, err = buildScaleRequest(redis, models.ServiceTierDeveloper, 1, nil)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	_, _, err = buildScaleRequest(postgres, "", 2, nil)
+	assert.ErrorContains(t, err, "single node")
+
+	_, _, err = buildScaleRequest(redis, "", -1, nil)
+	assert.ErrorContains(t, err, "at least 1")
+
+	_, _, err = buildScaleRequest(redis, "invalid-tier", 0, nil)
+	assert.Error(t, err)
+}
+
+func TestRenderProvenance(t *testing.T) {
+	var buf bytes.Buffer
+	renderProvenance(&buf, map[string]string{"team": "web"})
+	assert.Empty(t, buf.String())
+
+	renderProvenance(&buf, map[string]string{
+		"quickspin.io/git-commit": "0123456789abcdef",
+		"quickspin.io/git-branch": "main",
+		"quickspin.io/git-repo":   "https://github.com/acme/app.git",
+		"quickspin.io/dirty":      "true",
+	})
+	assert.Equal(t, `
+Deployed from:  0123456 (main, dirty)
+Repository:     https://github.com/acme/app.git
+Commit:         0123456789abcdef
+`, buf.String())
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	updateName        string
+	updateDescription string
+	updateSet         []string
+	updateUnset       []string
+	updateLabels      []string
+	updateYes         bool
+)
+
+// NewUpdateCmd creates the service update command
+func NewUpdateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update SERVICE",
+		Short: "Update a service's name, description, config or labels",
+		Long: `Update a service identified by ID, name or unique name prefix.
+
+Config values are typed: numbers and true/false are sent as such, quote a
+value to force a string. Labels are added with --label key=value and removed
+with --label key-. The changes are shown before they are applied.`,
+		Example: `  qspin service update my-redis --set maxmemory=512mb --unset persistence
+  qspin service update my-redis --label team=backend --label owner-`,
+		Args: cobra.ExactArgs(1),
+		RunE: runUpdate,
+	}
+
+	cmd.Flags().StringVar(&updateName, "name", "", "New service name")
+	cmd.Flags().StringVar(&updateDescription, "description", "", "New service description")
+	cmd.Flags().StringArrayVar(&updateSet, "set", nil, "Set a config value (key=value, repeatable)")
+	cmd.Flags().StringArrayVar(&updateUnset, "unset", nil, "Remove a config key (repeatable)")
+	cmd.Flags().StringArrayVar(&updateLabels, "label", nil, "Set a label (key=value) or remove it (key-), repeatable")
+	cmd.Flags().BoolVarP(&updateYes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// updateOptions holds the requested changes to a service
+type updateOptions struct {
+	Name        *string
+	Description *string
+	Set         []string
+	Unset       []string
+	Labels      []string
+}
+
+// fieldChange describes a single changed field for the before/after diff
+type fieldChange struct {
+	Key    string
+	Old    interface{}
+	New    interface{}
+	HadOld bool
+	HasNew bool
+}
+
+// String renders the change as a diff line
+func (c fieldChange) String() string {
+	switch {
+	case !c.HadOld:
+		return fmt.Sprintf("+ %s: %s", c.Key, formatValue(c.New))
+	case !c.HasNew:
+		return fmt.Sprintf("- %s: %s", c.Key, formatValue(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Key, formatValue(c.Old), formatValue(c.New))
+	}
+}
+
+// formatValue renders a config or label value for display
+func formatValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// buildUpdate computes the update request and the resulting changes for a service.
+// Changes that would leave a value as it is are dropped.
+func buildUpdate(svc *models.Service, opts updateOptions) (api.UpdateServiceRequest, []fieldChange, error) {
+	var req api.UpdateServiceRequest
+	var changes []fieldChange
+
+	if opts.Name != nil && *opts.Name != svc.Name {
+		req.Name = opts.Name
+		changes = append(changes, fieldChange{Key: "name", Old: svc.Name, New: *opts.Name, HadOld: true, HasNew: true})
+	}
+	if opts.Description != nil {
+		// The API does not return descriptions, so the previous value is unknown
+		req.Description = opts.Description
+		changes = append(changes, fieldChange{Key: "description", New: *opts.Description, HasNew: true})
+	}
+
+	setValues, err := parseConfigFlags(opts.Set)
+	if err != nil {
+		return req, nil, err
+	}
+	for _, key := range opts.Unset {
+		if _, ok := setValues[key]; ok {
+			return req, nil, fmt.Errorf("config key %q is both set and unset", key)
+		}
+	}
+
+	configPatch := make(map[string]interface{})
+	for key, value := range setValues {
+		old, had := svc.Config[key]
+		if had && fmt.Sprint(old) == fmt.Sprint(value) {
+			continue
+		}
+		configPatch[key] = value
+		changes = append(changes, fieldChange{Key: "config." + key, Old: old, New: value, HadOld: had, HasNew: true})
+	}
+	for _, key := range opts.Unset {
+		old, had := svc.Config[key]
+		if !had {
+			continue
+		}
+		configPatch[key] = nil
+		changes = append(changes, fieldChange{Key: "config." + key, Old: old, HadOld: true})
+	}
+	if len(configPatch) > 0 {
+		req.Config = configPatch
+	}
+
+	labelPatch := make(map[string]*string)
+	for _, arg := range opts.Labels {
+		if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+			old, had := svc.Labels[key]
+			if !had {
+				continue
+			}
+			labelPatch[key] = nil
+			changes = append(changes, fieldChange{Key: "labels." + key, Old: old, HadOld: true})
+			continue
+		}
+
+		key, value, err := splitKeyValue(arg)
+		if err != nil {
+			return req, nil, fmt.Errorf("invalid label: %w", err)
+		}
+		old, had := svc.Labels[key]
+		if had && old == value {
+			continue
+		}
+		labelPatch[key] = &value
+		changes = append(changes, fieldChange{Key: "labels." + key, Old: old, New: value, HadOld: had, HasNew: true})
+	}
+	if len(labelPatch) > 0 {
+		req.Labels = labelPatch
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		// Keep name/description first, then config and labels alphabetically
+		pi, pj := strings.Contains(changes[i].Key, "."), strings.Contains(changes[j].Key, ".")
+		if pi != pj {
+			return !pi
+		}
+		return pi && changes[i].Key < changes[j].Key
+	})
+
+	return req, changes, nil
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	serviceRef := args[0]
+
+	opts := updateOptions{
+		Set:    updateSet,
+		Unset:  updateUnset,
+		Labels: updateLabels,
+	}
+	if cmd.Flags().Changed("name") {
+		opts.Name = &updateName
+	}
+	if cmd.Flags().Changed("description") {
+		opts.Description = &updateDescription
+	}
+
+	ctx := context.Background()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	// Get current service state to compute the diff
+	service, err := getServiceByRef(ctx, client, serviceRef)
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+
+	req, changes, err := buildUpdate(service, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		outputpkg.Info(fmt.Sprintf("No changes to apply to service '%s'", service.Name))
+		return nil
+	}
+
+	fmt.Printf("Changes to service '%s' (%s):\n", service.Name, service.ID)
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+	fmt.Println()
+
+	if !updateYes && !outputpkg.Confirm("Apply these changes?") {
+		outputpkg.Info("Update cancelled")
+		return nil
+	}
+
+	// Show spinner
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("Updating service '%s'...", service.Name))
+	spinner.Start()
+
+	// Update service
+	updated, err := client.UpdateService(ctx, service.ID, req)
+	spinner.Stop()
+
+	if err != nil {
+		outputpkg.Error(fmt.Sprintf("Failed to update service: %s", err))
+		return err
+	}
+
+	// Success message
+	outputpkg.Success(fmt.Sprintf("Successfully updated service '%s'", updated.Name))
+	fmt.Println()
+
+	formatType := outputpkg.Format(viper.GetString("defaults.output"))
+	return outputpkg.Print(formatType, updated)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// splitKeyValue splits a key=value flag argument
+func splitKeyValue(arg string) (string, string, error) {
+	key, value, ok := strings.Cut(arg, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid value %q (expected key=value)", arg)
+	}
+	return key, value, nil
+}
+
+// parseConfigValue converts a flag value into a typed config value:
+// integers, floats, booleans and bracketed lists such as [a,b,1] are
+// recognized, everything else is a string. Wrap a value in quotes to force
+// a string, e.g. --set 'port="6379"'.
+func parseConfigValue(raw string) interface{} {
+	if len(raw) >= 2 && raw[0] == '[' && raw[len(raw)-1] == ']' {
+		inner := strings.TrimSpace(raw[1 : len(raw)-1])
+		list := []interface{}{}
+		if inner == "" {
+			return list
+		}
+		for _, item := range strings.Split(inner, ",") {
+			list = append(list, parseConfigValue(strings.TrimSpace(item)))
+		}
+		return list
+	}
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		if s, err := strconv.Unquote(raw); err == nil {
+			return s
+		}
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+	switch strings.ToLower(raw) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return raw
+}
+
+// parseConfigFlags parses repeatable key=value flags into a typed config map
+func parseConfigFlags(args []string) (map[string]interface{}, error) {
+	config := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		key, value, err := splitKeyValue(arg)
+		if err != nil {
+			return nil, err
+		}
+		config[key] = parseConfigValue(value)
+	}
+	return config, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/config"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	outputpkg "github.com/quickspin/quickspin-cli/internal/output"
+	servicepkg "github.com/quickspin/quickspin-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// defaultWaitTimeout bounds how long --wait blocks
+const defaultWaitTimeout = 10 * time.Minute
+
+var (
+	waitFor     string
+	waitTimeout time.Duration
+)
+
+// NewWaitCmd creates the service wait command
+func NewWaitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait SERVICE",
+		Short: "Wait for a service to reach a status",
+		Long: `Block until a service reaches a status or is deleted.
+
+Exits non-zero if the service fails or the timeout expires, so it can gate
+CI pipelines on a database being ready.`,
+		Example: `  qspin service wait my-db --for status=running --timeout 5m
+  qspin service wait my-db --for delete`,
+		Args: cobra.ExactArgs(1),
+		RunE: runWait,
+	}
+
+	cmd.Flags().StringVar(&waitFor, "for", "status=running", "Condition to wait for: status=STATUS or delete")
+	cmd.Flags().DurationVar(&waitTimeout, "timeout", defaultWaitTimeout, "Maximum time to wait")
+
+	return cmd
+}
+
+// parseWaitCondition parses a --for value
+func parseWaitCondition(s string) (servicepkg.Condition, error) {
+	if s == "delete" || s == "deleted" {
+		return servicepkg.Condition{Deleted: true}, nil
+	}
+
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key != "status" || value == "" {
+		return servicepkg.Condition{}, fmt.Errorf("invalid --for value %q (expected status=STATUS or delete)", s)
+	}
+
+	status := models.ServiceStatus(value)
+	switch status {
+	case models.ServiceStatusPending, models.ServiceStatusCreating, models.ServiceStatusRunning,
+		models.ServiceStatusStopped, models.ServiceStatusFailed, models.ServiceStatusDeleting:
+		return servicepkg.Condition{Status: status}, nil
+	}
+	return servicepkg.Condition{}, fmt.Errorf("unknown status %q", value)
+}
+
+// waitWithProgress waits for a service while showing a spinner with its current status
+func waitWithProgress(ctx context.Context, client servicepkg.StatusGetter, svc *models.Service, cond servicepkg.Condition, timeout time.Duration) (*models.Service, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	spinner := outputpkg.NewSpinner(fmt.Sprintf("Waiting for '%s' to %s...", svc.Name, cond))
+	spinner.Start()
+
+	result, err := servicepkg.Wait(ctx, client, svc.ID, cond, func(current *models.Service) {
+		spinner.UpdateMessage(fmt.Sprintf("Waiting for '%s' to %s (currently %s, %s elapsed)...",
+			svc.Name, cond, current.Status, time.Since(start).Round(time.Second)))
+	})
+	spinner.Stop()
+
+	return result, err
+}
+
+func runWait(cmd *cobra.Command, args []string) error {
+	serviceRef := args[0]
+
+	cond, err := parseWaitCondition(waitFor)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Load config
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create API client
+	client := api.NewClient(cfg)
+
+	service, err := ResolveService(ctx, client, serviceRef)
+	if err != nil {
+		if cond.Deleted && errors.Is(err, api.ErrNotFound) {
+			outputpkg.Success(fmt.Sprintf("Service '%s' is deleted", serviceRef))
+			return nil
+		}
+		outputpkg.Error(fmt.Sprintf("Failed to get service: %s", err))
+		return err
+	}
+
+	result, err := waitWithProgress(ctx, client, service, cond, waitTimeout)
+	if err != nil {
+		outputpkg.Error(err.Error())
+		return err
+	}
+
+	if cond.Deleted {
+		outputpkg.Success(fmt.Sprintf("Service '%s' is deleted", service.Name))
+		return nil
+	}
+
+	outputpkg.Success(fmt.Sprintf("Service '%s' is %s", result.Name, result.Status))
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVersionCmd(t *testing.T) {
@@ -53,3 +55,14 @@ func TestVersionCmd_DevVersion(t *testing.T) {
 	assert.Contains(t, output, "commit: none")
 	assert.Contains(t, output, "built: unknown")
 }
+
+func TestOutputFlagSetsDefaultOutput(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("output")
+	defer func() {
+		_ = flag.Value.Set("")
+		flag.Changed = false
+	}()
+
+	require.NoError(t, rootCmd.PersistentFlags().Parse([]string{"-o", "json"}))
+	assert.Equal(t, "json", viper.GetString("defaults.output"))
+}
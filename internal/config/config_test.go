@@ -100,3 +100,41 @@ func TestGetDefaultValues(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadPreset(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	_, err := LoadPreset("redis-cache")
+	assert.ErrorContains(t, err, "no presets")
+
+	presetsDir := filepath.Join(tempDir, ".quickspin", "presets")
+	require.NoError(t, os.MkdirAll(presetsDir, 0755))
+	content := `description: Cache defaults
+type: redis
+config:
+  maxmemory: 256mb
+  databases: 4
+labels:
+  team: backend
+`
+	require.NoError(t, os.WriteFile(filepath.Join(presetsDir, "redis-cache.yaml"), []byte(content), 0644))
+
+	preset, err := LoadPreset("redis-cache")
+	require.NoError(t, err)
+	assert.Equal(t, "redis-cache", preset.Name)
+	assert.Equal(t, "redis", preset.Type)
+	assert.Equal(t, "256mb", preset.Config["maxmemory"])
+	assert.Equal(t, 4, preset.Config["databases"])
+	assert.Equal(t, "backend", preset.Labels["team"])
+
+	names, err := ListPresets()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"redis-cache"}, names)
+
+	_, err = LoadPreset("missing")
+	assert.ErrorContains(t, err, "available: redis-cache")
+
+	_, err = LoadPreset("../secrets")
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Preset is a named set of service settings stored under ~/.quickspin/presets/NAME.yaml
+type Preset struct {
+	Name        string                 `yaml:"-"`
+	Description string                 `yaml:"description,omitempty"`
+	Type        string                 `yaml:"type,omitempty"`
+	Tier        string                 `yaml:"tier,omitempty"`
+	Config      map[string]interface{} `yaml:"config,omitempty"`
+	Labels      map[string]string      `yaml:"labels,omitempty"`
+}
+
+// GetPresetsDir returns the directory holding service presets
+func GetPresetsDir() string {
+	return filepath.Join(New().GetConfigDir(), "presets")
+}
+
+// LoadPreset loads a preset by name
+func LoadPreset(name string) (*Preset, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid preset name %q", name)
+	}
+
+	var data []byte
+	var err error
+	for _, ext := range []string{".yaml", ".yml"} {
+		data, err = os.ReadFile(filepath.Join(GetPresetsDir(), name+ext))
+		if err == nil || !os.IsNotExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			available, _ := ListPresets()
+			if len(available) == 0 {
+				return nil, fmt.Errorf("preset %q not found (no presets in %s)", name, GetPresetsDir())
+			}
+			return nil, fmt.Errorf("preset %q not found (available: %s)", name, strings.Join(available, ", "))
+		}
+		return nil, fmt.Errorf("failed to read preset: %w", err)
+	}
+
+	var preset Preset
+	if err := yaml.Unmarshal(data, &preset); err != nil {
+		return nil, fmt.Errorf("failed to parse preset %q: %w", name, err)
+	}
+	preset.Name = name
+
+	return &preset, nil
+}
+
+// ListPresets returns the names of all stored presets
+func ListPresets() ([]string, error) {
+	entries, err := os.ReadDir(GetPresetsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read presets directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext == ".yaml" || ext == ".yml" {
+			names = append(names, strings.TrimSuffix(entry.Name(), ext))
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -0,0 +1,321 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Credentials used by the containers of a generated compose file. They only
+// protect services bound to localhost.
+const (
+	composeUser     = "quickspin"
+	composePassword = "quickspin"
+	composeDatabase = "app"
+)
+
+// ComposeFile is a docker-compose file
+type ComposeFile struct {
+	Services map[string]ComposeService    `yaml:"services"`
+	Volumes  map[string]map[string]string `yaml:"volumes,omitempty"`
+}
+
+// ComposeService is a container definition in a docker-compose file
+type ComposeService struct {
+	Image       string            `yaml:"image"`
+	Command     []string          `yaml:"command,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Volumes     []string          `yaml:"volumes,omitempty"`
+	DependsOn   []string          `yaml:"depends_on,omitempty"`
+}
+
+// ComposeCredentials are the local connection details of one service
+type ComposeCredentials struct {
+	Name        string
+	Type        models.ServiceType
+	Credentials *models.ServiceCredentials
+}
+
+// ComposeProject is everything generated for running a deployment locally
+type ComposeProject struct {
+	File ComposeFile
+	// Credentials are the connection details of each service, in file order
+	Credentials []ComposeCredentials
+	// InitFiles are extra files the containers mount, by path relative to the
+	// compose file
+	InitFiles map[string]string
+	// Ignored lists the config keys that have no local equivalent
+	Ignored []string
+}
+
+// composeBuilder maps services to containers
+type composeBuilder struct {
+	project *ComposeProject
+	// ports are the host ports already taken
+	ports map[int]bool
+}
+
+// Compose maps the services of a deployment to containers for local
+// development, translating the config keys that have a local equivalent:
+// maxmemory, maxmemory-policy and persistence for Redis, vhost for RabbitMQ,
+// database for PostgreSQL, MongoDB and MySQL, and extensions for PostgreSQL.
+// Host ports start at each type's default and move up when taken.
+func Compose(cfg *models.DeploymentConfig) (*ComposeProject, error) {
+	b := &composeBuilder{
+		project: &ComposeProject{
+			File: ComposeFile{
+				Services: make(map[string]ComposeService, len(cfg.Services)),
+				Volumes:  make(map[string]map[string]string),
+			},
+			InitFiles: make(map[string]string),
+		},
+		ports: make(map[int]bool),
+	}
+
+	for _, tmpl := range cfg.Services {
+		svc, creds, err := b.service(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		svc.DependsOn = tmpl.DependsOn
+		b.project.File.Services[tmpl.Name] = svc
+		b.project.Credentials = append(b.project.Credentials, ComposeCredentials{
+			Name:        tmpl.Name,
+			Type:        tmpl.Type,
+			Credentials: creds,
+		})
+	}
+
+	sort.Strings(b.project.Ignored)
+	return b.project, nil
+}
+
+// service returns the container definition and local credentials of a service
+func (b *composeBuilder) service(tmpl models.ServiceTemplate) (ComposeService, *models.ServiceCredentials, error) {
+	config := configReader{tmpl: tmpl, used: make(map[string]bool)}
+	defer config.reportUnused(&b.project.Ignored)
+
+	volume := tmpl.Name + "-data"
+	creds := &models.ServiceCredentials{Host: "localhost"}
+	var svc ComposeService
+
+	switch tmpl.Type {
+	case models.ServiceTypeRedis:
+		creds.Port = b.port(6379)
+		creds.Password = composePassword
+		svc = ComposeService{
+			Image:   "redis:7-alpine",
+			Command: []string{"redis-server", "--requirepass", composePassword},
+			Ports:   []string{portMapping(creds.Port, 6379)},
+			Volumes: []string{volume + ":/data"},
+		}
+		if v := config.string("maxmemory"); v != "" {
+			svc.Command = append(svc.Command, "--maxmemory", v)
+		}
+		if v := config.string("maxmemory-policy"); v != "" {
+			svc.Command = append(svc.Command, "--maxmemory-policy", v)
+		}
+		if v := config.string("persistence"); v == "enabled" || v == "true" || v == "aof" {
+			svc.Command = append(svc.Command, "--appendonly", "yes")
+		}
+
+	case models.ServiceTypeRabbitMQ:
+		creds.Port = b.port(5672)
+		creds.Username = composeUser
+		creds.Password = composePassword
+		svc = ComposeService{
+			Image: "rabbitmq:3-management-alpine",
+			Environment: map[string]string{
+				"RABBITMQ_DEFAULT_USER": composeUser,
+				"RABBITMQ_DEFAULT_PASS": composePassword,
+			},
+			Ports:   []string{portMapping(creds.Port, 5672)},
+			Volumes: []string{volume + ":/var/lib/rabbitmq"},
+		}
+		if v := config.string("vhost"); v != "" {
+			svc.Environment["RABBITMQ_DEFAULT_VHOST"] = v
+			creds.Database = v
+		}
+		if v := config.string("management_enabled"); v == "false" {
+			svc.Image = "rabbitmq:3-alpine"
+		} else {
+			svc.Ports = append(svc.Ports, portMapping(b.port(15672), 15672))
+		}
+
+	case models.ServiceTypeElasticsearch:
+		creds.Port = b.port(9200)
+		creds.URI = fmt.Sprintf("http://localhost:%d", creds.Port)
+		svc = ComposeService{
+			Image: "docker.elastic.co/elasticsearch/elasticsearch:8.15.0",
+			Environment: map[string]string{
+				"discovery.type":         "single-node",
+				"xpack.security.enabled": "false",
+				"ES_JAVA_OPTS":           "-Xms512m -Xmx512m",
+			},
+			Ports:   []string{portMapping(creds.Port, 9200)},
+			Volumes: []string{volume + ":/usr/share/elasticsearch/data"},
+		}
+		// A local cluster always has a single node
+		config.string("nodes")
+
+	case models.ServiceTypePostgreSQL:
+		creds.Port = b.port(5432)
+		creds.Username = composeUser
+		creds.Password = composePassword
+		creds.Database = config.stringOr("database", composeDatabase)
+		svc = ComposeService{
+			Image: "postgres:16-alpine",
+			Environment: map[string]string{
+				"POSTGRES_USER":     composeUser,
+				"POSTGRES_PASSWORD": composePassword,
+				"POSTGRES_DB":       creds.Database,
+			},
+			Ports:   []string{portMapping(creds.Port, 5432)},
+			Volumes: []string{volume + ":/var/lib/postgresql/data"},
+		}
+		if extensions := config.list("extensions"); len(extensions) > 0 {
+			path := fmt.Sprintf("initdb/%s.sql", tmpl.Name)
+			var sql strings.Builder
+			for _, ext := range extensions {
+				fmt.Fprintf(&sql, "CREATE EXTENSION IF NOT EXISTS %q;\n", ext)
+				if ext == "pg_stat_statements" {
+					svc.Command = []string{"postgres", "-c", "shared_preload_libraries=pg_stat_statements"}
+				}
+			}
+			b.project.InitFiles[path] = sql.String()
+			svc.Volumes = append(svc.Volumes, "./"+path+":/docker-entrypoint-initdb.d/extensions.sql:ro")
+		}
+
+	case models.ServiceTypeMongoDB:
+		creds.Port = b.port(27017)
+		creds.Database = config.stringOr("database", composeDatabase)
+		svc = ComposeService{
+			Image:       "mongo:7",
+			Environment: map[string]string{"MONGO_INITDB_DATABASE": creds.Database},
+			Ports:       []string{portMapping(creds.Port, 27017)},
+			Volumes:     []string{volume + ":/data/db"},
+		}
+		if config.string("auth_enabled") != "false" {
+			creds.Username = composeUser
+			creds.Password = composePassword
+			svc.Environment["MONGO_INITDB_ROOT_USERNAME"] = composeUser
+			svc.Environment["MONGO_INITDB_ROOT_PASSWORD"] = composePassword
+			// The root user is defined in the admin database
+			creds.URI = fmt.Sprintf("mongodb://%s:%s@localhost:%d/%s?authSource=admin",
+				composeUser, composePassword, creds.Port, creds.Database)
+		}
+
+	case models.ServiceTypeMySQL:
+		creds.Port = b.port(3306)
+		creds.Username = composeUser
+		creds.Password = composePassword
+		creds.Database = config.stringOr("database", composeDatabase)
+		svc = ComposeService{
+			Image: "mysql:8.4",
+			Environment: map[string]string{
+				"MYSQL_ROOT_PASSWORD": composePassword,
+				"MYSQL_USER":          composeUser,
+				"MYSQL_PASSWORD":      composePassword,
+				"MYSQL_DATABASE":      creds.Database,
+			},
+			Ports:   []string{portMapping(creds.Port, 3306)},
+			Volumes: []string{volume + ":/var/lib/mysql"},
+		}
+
+	default:
+		return ComposeService{}, nil, fmt.Errorf("service %q: no local container for type %q", tmpl.Name, tmpl.Type)
+	}
+
+	b.project.File.Volumes[volume] = map[string]string{}
+	return svc, creds, nil
+}
+
+// port returns the first free host port from a service type's default
+func (b *composeBuilder) port(preferred int) int {
+	port := preferred
+	for b.ports[port] {
+		port++
+	}
+	b.ports[port] = true
+	return port
+}
+
+// portMapping binds a container port to a host port on localhost only
+func portMapping(host, container int) string {
+	return fmt.Sprintf("127.0.0.1:%d:%d", host, container)
+}
+
+// configReader reads config values and tracks which keys were translated
+type configReader struct {
+	tmpl models.ServiceTemplate
+	used map[string]bool
+}
+
+// string returns a config value as a string, or "" if it is not set
+func (c configReader) string(key string) string {
+	c.used[key] = true
+	v, ok := c.tmpl.Config[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// stringOr returns a config value as a string, or def if it is not set
+func (c configReader) stringOr(key, def string) string {
+	if v := c.string(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// list returns a config value given as a list or a comma-separated string
+func (c configReader) list(key string) []string {
+	c.used[key] = true
+	var items []string
+	switch v := c.tmpl.Config[key].(type) {
+	case []interface{}:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	case []string:
+		items = v
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// reportUnused records the config keys that were not translated
+func (c configReader) reportUnused(ignored *[]string) {
+	for _, key := range sortedKeys(c.tmpl.Config) {
+		if !c.used[key] {
+			*ignored = append(*ignored, c.tmpl.Name+": config."+key)
+		}
+	}
+}
+
+// FormatCompose encodes a compose file as YAML with a header comment
+func FormatCompose(file ComposeFile) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("# Generated by qspin deploy compose for local development.\n")
+	b.WriteString("# Connection variables are in the .env file next to this one.\n")
+
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,169 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// DriftStatus describes how a live service compares with the file
+type DriftStatus string
+
+const (
+	DriftInSync    DriftStatus = "in_sync"
+	DriftChanged   DriftStatus = "drifted"
+	DriftMissing   DriftStatus = "missing"
+	DriftUnmanaged DriftStatus = "unmanaged"
+)
+
+// Difference is a field whose live value differs from the file
+type Difference struct {
+	Field    string      `json:"field"`
+	Expected interface{} `json:"expected"`
+	Live     interface{} `json:"live"`
+}
+
+// ServiceDrift is the drift status of one service
+type ServiceDrift struct {
+	Name        string             `json:"name"`
+	Type        models.ServiceType `json:"type"`
+	Status      DriftStatus        `json:"status"`
+	ServiceID   string             `json:"service_id,omitempty"`
+	Differences []Difference       `json:"differences,omitempty"`
+	// DeployedFrom is the git provenance recorded on the live service
+	DeployedFrom *Provenance `json:"deployed_from,omitempty"`
+}
+
+// DriftReport compares a deployment file with the live services
+type DriftReport struct {
+	InSync    bool           `json:"in_sync"`
+	CheckedAt time.Time      `json:"checked_at"`
+	Services  []ServiceDrift `json:"services"`
+}
+
+// DriftOptions controls drift detection
+type DriftOptions struct {
+	// IncludeUnmanaged reports live services that are not in the file as drift
+	IncludeUnmanaged bool
+}
+
+// DetectDrift reports services whose live state no longer matches the file
+func DetectDrift(cfg *models.DeploymentConfig, live []models.Service, opts DriftOptions, now time.Time) *DriftReport {
+	plan := BuildPlan(cfg, live, PlanOptions{Prune: opts.IncludeUnmanaged, PruneAll: true})
+
+	byID := make(map[string]*models.Service, len(live))
+	for i := range live {
+		byID[live[i].ID] = &live[i]
+	}
+
+	report := &DriftReport{InSync: true, CheckedAt: now}
+	for _, svc := range plan.Services {
+		drift := ServiceDrift{Name: svc.Name, Type: svc.Type, ServiceID: svc.ServiceID}
+		if liveSvc, ok := byID[svc.ServiceID]; ok && svc.ServiceID != "" {
+			drift.DeployedFrom = ProvenanceFromLabels(liveSvc.Labels)
+		}
+		switch svc.Action {
+		case ActionUnchanged:
+			drift.Status = DriftInSync
+		case ActionCreate:
+			drift.Status = DriftMissing
+		case ActionDelete:
+			drift.Status = DriftUnmanaged
+		case ActionUpdate:
+			drift.Status = DriftChanged
+			for _, change := range svc.Changes {
+				drift.Differences = append(drift.Differences, Difference{
+					Field:    change.Field,
+					Expected: change.New,
+					Live:     change.Old,
+				})
+			}
+		}
+		if drift.Status != DriftInSync {
+			report.InSync = false
+		}
+		report.Services = append(report.Services, drift)
+	}
+
+	return report
+}
+
+// Drifted returns the services that are not in sync
+func (r *DriftReport) Drifted() []ServiceDrift {
+	var result []ServiceDrift
+	for _, svc := range r.Services {
+		if svc.Status != DriftInSync {
+			result = append(result, svc)
+		}
+	}
+	return result
+}
+
+// RenderText writes the services that drifted, optionally with ANSI colors
+func (r *DriftReport) RenderText(w io.Writer, color bool) {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	defer r.renderProvenance(w)
+
+	drifted := r.Drifted()
+	if len(drifted) == 0 {
+		fmt.Fprintf(w, "All %d service(s) are in sync.\n", len(r.Services))
+		return
+	}
+
+	fmt.Fprintf(w, "Drift detected in %d of %d service(s):\n\n", len(drifted), len(r.Services))
+	for _, svc := range drifted {
+		switch svc.Status {
+		case DriftMissing:
+			fmt.Fprintf(w, "%s %s (%s): in the file but not deployed\n", paint(colorRed, "!"), svc.Name, svc.Type)
+		case DriftUnmanaged:
+			fmt.Fprintf(w, "%s %s (%s): deployed but not in the file\n", paint(colorYellow, "?"), svc.Name, svc.Type)
+		default:
+			fmt.Fprintf(w, "%s %s (%s)\n", paint(colorYellow, "~"), svc.Name, svc.Type)
+			for _, d := range svc.Differences {
+				live := "(unset)"
+				if d.Live != nil {
+					live = formatValue(d.Live)
+				}
+				fmt.Fprintf(w, "    %s: expected %s, live %s\n", d.Field, formatValue(d.Expected), paint(colorYellow, live))
+			}
+		}
+	}
+}
+
+// renderProvenance lists the commit each live service was last deployed
+// from, when any service records one
+func (r *DriftReport) renderProvenance(w io.Writer) {
+	width := 0
+	for _, svc := range r.Services {
+		if svc.DeployedFrom != nil {
+			width = max(width, len(svc.Name))
+		}
+	}
+	if width == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Last deployed from:")
+	for _, svc := range r.Services {
+		if svc.DeployedFrom != nil {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, svc.Name, svc.DeployedFrom)
+		}
+	}
+}
+
+// RenderJSON writes the full report as indented JSON
+func (r *DriftReport) RenderJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
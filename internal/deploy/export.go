@@ -0,0 +1,207 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// ManagedLabelPrefix marks labels that QuickSpin sets itself
+const ManagedLabelPrefix = "quickspin.io/"
+
+// sensitiveWords are config key words that mark a credential
+var sensitiveWords = map[string]bool{
+	"password":    true,
+	"passwd":      true,
+	"secret":      true,
+	"token":       true,
+	"credential":  true,
+	"credentials": true,
+	"apikey":      true,
+	"privatekey":  true,
+	"accesskey":   true,
+}
+
+// keyQualifiers are words that make a following "key" a credential, as in api_key
+var keyQualifiers = map[string]bool{
+	"api":        true,
+	"private":    true,
+	"access":     true,
+	"license":    true,
+	"encryption": true,
+	"signing":    true,
+	"secret":     true,
+}
+
+var keyWordPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SensitiveKey reports whether a config key names a credential, e.g.
+// password, admin_token or api-key
+func SensitiveKey(key string) bool {
+	words := keyWordPattern.Split(strings.ToLower(key), -1)
+	for i, word := range words {
+		if sensitiveWords[word] {
+			return true
+		}
+		if word == "key" && i > 0 && keyQualifiers[words[i-1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasURLPassword reports whether a value is a URL with a password, such as a
+// connection string
+func hasURLPassword(v interface{}) bool {
+	s, ok := v.(string)
+	if !ok || !strings.Contains(s, "://") {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	_, ok = u.User.Password()
+	return ok
+}
+
+// FromServices builds a deployment config from live services, for when the
+// API cannot export one. Preview copies are left out.
+func FromServices(organization string, services []models.Service) *models.DeploymentConfig {
+	cfg := &models.DeploymentConfig{
+		Version:      SupportedVersion,
+		Organization: organization,
+	}
+	for _, svc := range services {
+		if isPreview(svc.Labels) {
+			continue
+		}
+		cfg.Services = append(cfg.Services, models.ServiceTemplate{
+			Name:   svc.Name,
+			Type:   svc.Type,
+			Tier:   svc.Tier,
+			Region: svc.Region,
+			Config: svc.Config,
+			Labels: svc.Labels,
+		})
+	}
+	return cfg
+}
+
+// isPreview reports whether labels mark a preview copy of a service
+func isPreview(labels map[string]string) bool {
+	_, ok := labels[PreviewLabel]
+	return ok
+}
+
+// CleanExport prepares an exported deployment for committing: services are
+// sorted by name, and preview copies, credentials and labels managed by
+// QuickSpin are removed. It returns the cleaned copy and the fields that were removed
+// because they hold credentials.
+func CleanExport(cfg *models.DeploymentConfig) (*models.DeploymentConfig, []string) {
+	clean := &models.DeploymentConfig{
+		Version:      cfg.Version,
+		Organization: cfg.Organization,
+		Services:     make([]models.ServiceTemplate, 0, len(cfg.Services)),
+	}
+	if clean.Version == "" {
+		clean.Version = SupportedVersion
+	}
+
+	var removed []string
+	for _, svc := range cfg.Services {
+		// Applying a preview copy would recreate it as a permanent service
+		if isPreview(svc.Labels) {
+			continue
+		}
+
+		tmpl := models.ServiceTemplate{
+			Name:   svc.Name,
+			Type:   svc.Type,
+			Tier:   svc.Tier,
+			Region: svc.Region,
+		}
+
+		if config := stripCredentials(svc.Config, svc.Name+".config", &removed); len(config) > 0 {
+			tmpl.Config = config
+		}
+
+		for key, value := range svc.Labels {
+			if strings.HasPrefix(key, ManagedLabelPrefix) {
+				continue
+			}
+			if tmpl.Labels == nil {
+				tmpl.Labels = make(map[string]string)
+			}
+			tmpl.Labels[key] = value
+		}
+
+		clean.Services = append(clean.Services, tmpl)
+	}
+
+	sort.SliceStable(clean.Services, func(i, j int) bool {
+		a, b := clean.Services[i], clean.Services[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Type < b.Type
+	})
+	sort.Strings(removed)
+
+	return clean, removed
+}
+
+// stripCredentials copies a config map without the keys that hold
+// credentials, recursing into nested maps and recording what it removed
+func stripCredentials(config map[string]interface{}, path string, removed *[]string) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(config))
+	for _, key := range sortedKeys(config) {
+		value := config[key]
+		if SensitiveKey(key) || hasURLPassword(value) {
+			*removed = append(*removed, path+"."+key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = stripCredentials(nested, path+"."+key, removed)
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// FormatExport encodes an exported deployment as YAML laid out like
+// configs/quickspin-services.example.yaml, with a blank line between services
+func FormatExport(cfg *models.DeploymentConfig) ([]byte, error) {
+	data, err := Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# QuickSpin deployment file")
+	fmt.Fprintln(&buf, "# Exported with: qspin deploy export")
+	fmt.Fprintln(&buf)
+
+	first := true
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "services:"):
+			buf.WriteString("\n")
+		case strings.HasPrefix(line, "  - "):
+			if !first {
+				buf.WriteString("\n")
+			}
+			first = false
+		}
+		buf.WriteString(line)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,224 @@
+// Package deploy loads, validates and plans GitOps deployment files.
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// SupportedVersion is the deployment file format version understood by the CLI
+const SupportedVersion = "1"
+
+// LoadOptions controls how deployment files are resolved
+type LoadOptions struct {
+	// EnvFiles are read in order to provide values for ${VAR} references.
+	// Variables set in the environment take precedence.
+	EnvFiles []string
+	// Overlays are deep-merged onto the base file in order
+	Overlays []string
+	// Lookup resolves variables instead of the environment and EnvFiles
+	Lookup LookupFunc
+}
+
+// LoadFile reads a deployment file, interpolating variables from the
+// environment. A path of "-" reads from stdin.
+func LoadFile(path string) (*models.DeploymentConfig, error) {
+	return Load(path, LoadOptions{})
+}
+
+// Load reads a deployment file, interpolates ${VAR} references in each file
+// and merges any overlays onto it, returning the fully resolved deployment
+func Load(path string, opts LoadOptions) (*models.DeploymentConfig, error) {
+	lookup := opts.Lookup
+	if lookup == nil {
+		values, err := LoadEnvFiles(opts.EnvFiles)
+		if err != nil {
+			return nil, err
+		}
+		lookup = EnvLookup(values)
+	}
+
+	doc, err := readDocument(path, lookup)
+	if err != nil {
+		return nil, err
+	}
+	for _, overlay := range opts.Overlays {
+		overlayDoc, err := readDocument(overlay, lookup)
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = mergeDocuments(doc, overlayDoc); err != nil {
+			return nil, fmt.Errorf("failed to merge %s: %w", overlay, err)
+		}
+	}
+
+	// Round-trip the merged document through YAML to decode it
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// readSource reads a file, or stdin for "-"
+func readSource(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment file: %w", err)
+	}
+	return data, nil
+}
+
+// readDocument reads one file and interpolates the variables in its values
+func readDocument(path string, lookup LookupFunc) (map[string]interface{}, error) {
+	data, err := readSource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var missing []string
+	interpolateNode(&root, lookup, &missing)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s: %w", path, &MissingVariablesError{Names: uniqueSorted(missing)})
+	}
+
+	doc := make(map[string]interface{})
+	if root.Kind == 0 {
+		return doc, nil
+	}
+	if err := root.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// interpolateNode interpolates every scalar value below a node, collecting
+// the names of missing variables. Mapping keys and comments are left alone.
+func interpolateNode(node *yaml.Node, lookup LookupFunc, missing *[]string) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		value, err := Interpolate(node.Value, lookup)
+		var missingErr *MissingVariablesError
+		if errors.As(err, &missingErr) {
+			*missing = append(*missing, missingErr.Names...)
+			return
+		}
+		if value != node.Value {
+			node.Value = value
+			if node.Style == 0 {
+				// Let plain scalars resolve to numbers and booleans again
+				node.Tag = ""
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			interpolateNode(node.Content[i], lookup, missing)
+		}
+	default:
+		for _, child := range node.Content {
+			interpolateNode(child, lookup, missing)
+		}
+	}
+}
+
+// Marshal encodes a resolved deployment config as YAML
+func Marshal(cfg *models.DeploymentConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Parse decodes a deployment file
+func Parse(data []byte) (*models.DeploymentConfig, error) {
+	var cfg models.DeploymentConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks a deployment config locally against the known service types
+// and tiers, or the catalog when one is given. It returns every problem found.
+func Validate(cfg *models.DeploymentConfig, catalog []models.ServiceTypeInfo) []error {
+	var errs []error
+
+	if cfg.Version != "" && cfg.Version != SupportedVersion {
+		errs = append(errs, fmt.Errorf("unsupported version %q (expected %q)", cfg.Version, SupportedVersion))
+	}
+	if len(cfg.Services) == 0 {
+		errs = append(errs, errors.New("no services defined"))
+	}
+
+	validator := models.NewValidator(catalog)
+	seen := make(map[string]bool, len(cfg.Services))
+	duplicates := false
+	for i, svc := range cfg.Services {
+		where := fmt.Sprintf("services[%d]", i)
+		if svc.Name != "" {
+			where = fmt.Sprintf("service %q", svc.Name)
+		}
+
+		if seen[svc.Name] {
+			errs = append(errs, fmt.Errorf("%s: defined more than once", where))
+			duplicates = true
+		}
+		seen[svc.Name] = true
+
+		if svc.Tier == "" {
+			// The server applies its default tier
+			svc.Tier = models.ServiceTierDeveloper
+		}
+		if err := validator.ValidateService(svc.Name, svc.Type, svc.Tier, svc.Region); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+		}
+		for _, key := range sortedKeys(svc.Labels) {
+			if err := models.ValidateLabelKey(key); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", where, err))
+			}
+		}
+		for _, key := range sortedKeys(svc.Config) {
+			for _, ref := range SecretRefs(svc.Config[key]) {
+				if strings.TrimSpace(ref.Target) == "" {
+					errs = append(errs, fmt.Errorf("%s: config.%s: %s is an empty secret reference", where, key, ref))
+				}
+			}
+		}
+	}
+
+	if !duplicates {
+		if _, err := BuildGraph(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
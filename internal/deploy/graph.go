@@ -0,0 +1,258 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// CycleError reports services that depend on each other in a loop
+type CycleError struct {
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Cycle, " -> ")
+}
+
+// Graph is the dependency graph of the services in a deployment
+type Graph struct {
+	// names are the services in file order
+	names        []string
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+// BuildGraph builds the dependency graph from the depends_on lists of a
+// deployment. It fails on unknown dependencies and on cycles.
+func BuildGraph(cfg *models.DeploymentConfig) (*Graph, error) {
+	g := &Graph{
+		dependencies: make(map[string][]string, len(cfg.Services)),
+		dependents:   make(map[string][]string, len(cfg.Services)),
+	}
+	for _, svc := range cfg.Services {
+		if _, ok := g.dependencies[svc.Name]; ok {
+			return nil, fmt.Errorf("service %q is defined more than once", svc.Name)
+		}
+		g.names = append(g.names, svc.Name)
+		g.dependencies[svc.Name] = nil
+	}
+
+	for _, svc := range cfg.Services {
+		for _, dep := range svc.DependsOn {
+			if _, ok := g.dependencies[dep]; !ok {
+				return nil, fmt.Errorf("service %q depends on unknown service %q", svc.Name, dep)
+			}
+			if containsString(g.dependencies[svc.Name], dep) {
+				continue
+			}
+			g.dependencies[svc.Name] = append(g.dependencies[svc.Name], dep)
+			g.dependents[dep] = append(g.dependents[dep], svc.Name)
+		}
+	}
+
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return g, nil
+}
+
+// findCycle returns the services on a dependency cycle, starting and ending
+// with the same service, or nil if there is none
+func (g *Graph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(g.names))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range g.dependencies[name] {
+			switch state[dep] {
+			case visiting:
+				for i, n := range path {
+					if n == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range g.names {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Dependencies returns the services a service depends on directly
+func (g *Graph) Dependencies(name string) []string {
+	return g.dependencies[name]
+}
+
+// Order returns the services so that each comes after its dependencies,
+// keeping file order otherwise
+func (g *Graph) Order() []string {
+	remaining := make(map[string]int, len(g.names))
+	for _, name := range g.names {
+		remaining[name] = len(g.dependencies[name])
+	}
+
+	var order []string
+	placed := make(map[string]bool, len(g.names))
+	for len(order) < len(g.names) {
+		for _, name := range g.names {
+			if placed[name] || remaining[name] > 0 {
+				continue
+			}
+			placed[name] = true
+			order = append(order, name)
+			for _, dependent := range g.dependents[name] {
+				remaining[dependent]--
+			}
+			// Restart so that earlier services in the file go first
+			break
+		}
+	}
+	return order
+}
+
+// HasDependencies reports whether any service depends on another
+func (g *Graph) HasDependencies() bool {
+	for _, deps := range g.dependencies {
+		if len(deps) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyState is the progress of one service during a parallel apply
+type ApplyState string
+
+const (
+	ApplyWaiting ApplyState = "waiting"
+	ApplyStarted ApplyState = "applying"
+	ApplyDone    ApplyState = "done"
+	ApplyFailed  ApplyState = "failed"
+	ApplySkipped ApplyState = "skipped"
+)
+
+// ApplyEvent reports a service changing state during Execute
+type ApplyEvent struct {
+	Service string
+	State   ApplyState
+	Err     error
+}
+
+// Execute calls fn for every service, at most parallelism at a time, starting
+// each service only once all of its dependencies have succeeded. Services
+// whose dependencies fail, or that have not started when ctx is cancelled,
+// are skipped. It returns the error of each service that did not succeed.
+func (g *Graph) Execute(ctx context.Context, parallelism int, fn func(ctx context.Context, name string) error, onEvent func(ApplyEvent)) map[string]error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	emit := func(name string, state ApplyState, err error) {
+		if onEvent != nil {
+			onEvent(ApplyEvent{Service: name, State: state, Err: err})
+		}
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+
+	remaining := make(map[string]int, len(g.names))
+	var ready []string
+	for _, name := range g.names {
+		remaining[name] = len(g.dependencies[name])
+		if remaining[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	errs := make(map[string]error)
+	finished := make(map[string]bool, len(g.names))
+	results := make(chan result)
+	running := 0
+
+	// skip marks a service and everything that depends on it as skipped
+	var skip func(name string, err error)
+	skip = func(name string, err error) {
+		if finished[name] {
+			return
+		}
+		finished[name] = true
+		errs[name] = err
+		emit(name, ApplySkipped, err)
+		for _, dependent := range g.dependents[name] {
+			skip(dependent, fmt.Errorf("dependency %q did not succeed", name))
+		}
+	}
+
+	for len(finished) < len(g.names) {
+		for running < parallelism && len(ready) > 0 && ctx.Err() == nil {
+			name := ready[0]
+			ready = ready[1:]
+			running++
+			emit(name, ApplyStarted, nil)
+			go func() {
+				results <- result{name: name, err: fn(ctx, name)}
+			}()
+		}
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		finished[r.name] = true
+		if r.err != nil {
+			errs[r.name] = r.err
+			emit(r.name, ApplyFailed, r.err)
+			for _, dependent := range g.dependents[r.name] {
+				skip(dependent, fmt.Errorf("dependency %q failed", r.name))
+			}
+			continue
+		}
+
+		emit(r.name, ApplyDone, nil)
+		for _, dependent := range g.dependents[r.name] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 && !finished[dependent] {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// Anything left was never started because the context ended
+	for _, name := range g.names {
+		if !finished[name] {
+			finished[name] = true
+			errs[name] = ctx.Err()
+			emit(name, ApplySkipped, ctx.Err())
+		}
+	}
+
+	return errs
+}
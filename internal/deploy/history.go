@@ -0,0 +1,161 @@
+package deploy
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// Masked stands in for a config value that holds a credential when past
+// deployments are compared
+type Masked struct{}
+
+func (Masked) String() string {
+	return "(sensitive)"
+}
+
+// MarshalJSON encodes the placeholder rather than the value
+func (m Masked) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// DiffConfigs compares the configurations of two deployments as the plan of
+// going from one to the other: services only in to are created, services only
+// in from are deleted, and changed settings are updates. Unlike BuildPlan,
+// keys removed in to are reported. Credentials are compared but masked.
+func DiffConfigs(from, to *models.DeploymentConfig) *Plan {
+	previous := make(map[string]models.ServiceTemplate, len(from.Services))
+	for _, tmpl := range from.Services {
+		previous[tmpl.Name] = tmpl
+	}
+
+	plan := &Plan{}
+	declared := make(map[string]bool, len(to.Services))
+	for _, tmpl := range to.Services {
+		declared[tmpl.Name] = true
+
+		old, ok := previous[tmpl.Name]
+		if !ok {
+			changes := creationChanges(tmpl)
+			for i, change := range changes {
+				if key, isConfig := strings.CutPrefix(change.Field, "config."); isConfig {
+					changes[i].New = maskValue(key, change.New)
+				}
+			}
+			plan.Services = append(plan.Services, ServicePlan{
+				Name:    tmpl.Name,
+				Type:    tmpl.Type,
+				Action:  ActionCreate,
+				Changes: changes,
+			})
+			continue
+		}
+
+		changes := diffTemplates(old, tmpl)
+		action := ActionUnchanged
+		if len(changes) > 0 {
+			action = ActionUpdate
+		}
+		plan.Services = append(plan.Services, ServicePlan{
+			Name:    tmpl.Name,
+			Type:    tmpl.Type,
+			Action:  action,
+			Changes: changes,
+		})
+	}
+
+	var deletes []ServicePlan
+	for _, tmpl := range from.Services {
+		if !declared[tmpl.Name] {
+			deletes = append(deletes, ServicePlan{Name: tmpl.Name, Type: tmpl.Type, Action: ActionDelete})
+		}
+	}
+	sort.Slice(deletes, func(i, j int) bool { return deletes[i].Name < deletes[j].Name })
+	plan.Services = append(plan.Services, deletes...)
+
+	return plan
+}
+
+// diffTemplates lists the settings that differ between two versions of a
+// service
+func diffTemplates(from, to models.ServiceTemplate) []Change {
+	var changes []Change
+	addString := func(field, before, after string) {
+		if before == after {
+			return
+		}
+		change := Change{Field: field}
+		if before != "" {
+			change.Old = before
+		}
+		if after != "" {
+			change.New = after
+		}
+		changes = append(changes, change)
+	}
+
+	addString("type", string(from.Type), string(to.Type))
+	addString("tier", string(from.Tier), string(to.Tier))
+	addString("region", from.Region, to.Region)
+
+	for _, key := range unionKeys(from.Config, to.Config) {
+		before, hadBefore := from.Config[key]
+		after, hasAfter := to.Config[key]
+		if hadBefore && hasAfter && equalValues(before, after) {
+			continue
+		}
+		change := Change{Field: "config." + key}
+		if hadBefore {
+			change.Old = maskValue(key, before)
+		}
+		if hasAfter {
+			change.New = maskValue(key, after)
+		}
+		changes = append(changes, change)
+	}
+
+	for _, key := range unionKeys(from.Labels, to.Labels) {
+		before, hadBefore := from.Labels[key]
+		after, hasAfter := to.Labels[key]
+		if hadBefore && hasAfter && before == after {
+			continue
+		}
+		change := Change{Field: "labels." + key}
+		if hadBefore {
+			change.Old = before
+		}
+		if hasAfter {
+			change.New = after
+		}
+		changes = append(changes, change)
+	}
+
+	addString("depends_on", strings.Join(from.DependsOn, ", "), strings.Join(to.DependsOn, ", "))
+
+	return changes
+}
+
+// maskValue hides credentials and redacts secret references in a config value
+func maskValue(key string, v interface{}) interface{} {
+	if HasSecret(v) {
+		return redact(v)
+	}
+	if SensitiveKey(key) || hasURLPassword(v) {
+		return Masked{}
+	}
+	return v
+}
+
+// unionKeys returns the keys of two maps in order
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := sortedKeys(a)
+	for _, key := range sortedKeys(b) {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,148 @@
+package deploy
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// variablePattern matches $$, ${VAR} and ${VAR:-default}. Other ${...}
+// forms are left untouched.
+var variablePattern = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// LookupFunc resolves a variable name, reporting whether it is set
+type LookupFunc func(name string) (string, bool)
+
+// MissingVariablesError lists variables that are referenced without a default but not set
+type MissingVariablesError struct {
+	Names []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("variable(s) not set: %s (set them in the environment or an --env-file, or use ${VAR:-default})",
+		strings.Join(e.Names, ", "))
+}
+
+// Interpolate replaces ${VAR} and ${VAR:-default} in s. $$ produces a literal $.
+// A variable that is unset, or set but empty, uses its default; a variable
+// without a default must be set.
+func Interpolate(s string, lookup LookupFunc) (string, error) {
+	var missing []string
+	result := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		groups := variablePattern.FindStringSubmatch(match)
+		name, hasDefault, def := groups[1], groups[2] != "", groups[3]
+
+		if value, ok := lookup(name); ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return def
+		}
+		missing = append(missing, name)
+		return match
+	})
+
+	if len(missing) > 0 {
+		return "", &MissingVariablesError{Names: missing}
+	}
+	return result, nil
+}
+
+// EnvLookup resolves variables from the process environment first and then
+// from the given values, e.g. those read from an env file
+func EnvLookup(fallback map[string]string) LookupFunc {
+	return func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := fallback[name]
+		return value, ok
+	}
+}
+
+// LoadEnvFile reads KEY=VALUE lines from a file. Blank lines, # comments and
+// an "export " prefix are ignored, and values may be single or double quoted.
+func LoadEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid quoted value", path, lineNo)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			// Strip trailing comments from unquoted values
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	return values, nil
+}
+
+// LoadEnvFiles reads several env files, later files overriding earlier ones
+func LoadEnvFiles(paths []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, path := range paths {
+		fileValues, err := LoadEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range fileValues {
+			values[k] = v
+		}
+	}
+	return values, nil
+}
+
+// uniqueSorted returns the distinct strings in order
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
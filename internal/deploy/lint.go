@@ -0,0 +1,242 @@
+package deploy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// LintIssue is a problem found in a deployment file
+type LintIssue struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+
+	// Warning marks an issue that does not fail the lint
+	Warning bool `json:"warning,omitempty"`
+
+	// Err is the validation error behind the issue, if there is one
+	Err error `json:"-"`
+}
+
+func (i LintIssue) String() string {
+	if i.Warning {
+		return fmt.Sprintf("%d:%d: warning: %s", i.Line, i.Column, i.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+}
+
+// LintFile reads and lints a deployment file. A path of "-" reads from stdin.
+func LintFile(path string, catalog []models.ServiceTypeInfo) ([]LintIssue, error) {
+	data, err := readSource(path)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := Lint(data, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return issues, nil
+}
+
+// Lint checks a deployment file without calling the API and reports where
+// each problem is: unknown and duplicate keys, duplicate service names,
+// invalid names, types, tiers and regions, bad label keys and unknown
+// dependencies. Values holding ${...} references are skipped, since they are
+// only known once resolved.
+func Lint(data []byte, catalog []models.ServiceTypeInfo) ([]LintIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	l := &linter{validator: models.NewValidator(catalog)}
+	if len(doc.Content) == 0 {
+		l.report(&doc, "no services defined")
+		return l.issues, nil
+	}
+	l.lintDocument(doc.Content[0])
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i], l.issues[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.issues, nil
+}
+
+// linter collects issues while walking a deployment file
+type linter struct {
+	validator *models.Validator
+	issues    []LintIssue
+	// dependencies are the depends_on entries, checked once all names are known
+	dependencies []*yaml.Node
+}
+
+// report records an issue at a node
+func (l *linter) report(node *yaml.Node, format string, args ...interface{}) {
+	line, column := node.Line, node.Column
+	if line == 0 {
+		line, column = 1, 1
+	}
+	l.issues = append(l.issues, LintIssue{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// mapping checks that a node is a mapping and returns its keys and values by
+// key, reporting duplicate keys and keys not in known
+func (l *linter) mapping(node *yaml.Node, what string, known []string) (map[string]*yaml.Node, map[string]*yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		l.report(node, "%s must be a mapping", what)
+		return nil, nil
+	}
+
+	keys := make(map[string]*yaml.Node)
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if first, ok := keys[key.Value]; ok {
+			l.report(key, "duplicate key %q in %s (first at line %d)", key.Value, what, first.Line)
+			continue
+		}
+		keys[key.Value] = key
+		values[key.Value] = value
+
+		if known != nil && !containsString(known, key.Value) {
+			msg := fmt.Sprintf("unknown key %q in %s", key.Value, what)
+			if suggestion := models.Suggest(key.Value, known); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %s?)", suggestion)
+			}
+			l.report(key, "%s", msg)
+		}
+	}
+	return keys, values
+}
+
+// lintDocument checks the top level of a deployment file
+func (l *linter) lintDocument(root *yaml.Node) {
+	_, values := l.mapping(root, "deployment file", fieldNames(reflect.TypeOf(models.DeploymentConfig{})))
+	if values == nil {
+		return
+	}
+
+	if version, ok := values["version"]; ok && !isTemplated(version) && version.Value != SupportedVersion {
+		l.report(version, "unsupported version %q (expected %q)", version.Value, SupportedVersion)
+	}
+
+	services, ok := values["services"]
+	switch {
+	case !ok || services.Tag == "!!null":
+		l.report(root, "no services defined")
+		return
+	case services.Kind != yaml.SequenceNode:
+		l.report(services, "services must be a list")
+		return
+	case len(services.Content) == 0:
+		l.report(services, "no services defined")
+		return
+	}
+
+	serviceKeys := fieldNames(reflect.TypeOf(models.ServiceTemplate{}))
+	names := make(map[string]*yaml.Node)
+	for i, item := range services.Content {
+		l.lintService(item, fmt.Sprintf("services[%d]", i), serviceKeys, names)
+	}
+
+	known := sortedKeys(names)
+	for _, dep := range l.dependencies {
+		if _, ok := names[dep.Value]; ok || isTemplated(dep) {
+			continue
+		}
+		msg := fmt.Sprintf("depends_on: unknown service %q", dep.Value)
+		if suggestion := models.Suggest(dep.Value, known); suggestion != "" {
+			msg += fmt.Sprintf(" (did you mean %s?)", suggestion)
+		}
+		l.report(dep, "%s", msg)
+	}
+}
+
+// lintService checks one service definition
+func (l *linter) lintService(node *yaml.Node, where string, known []string, names map[string]*yaml.Node) {
+	_, values := l.mapping(node, where, known)
+	if values == nil {
+		return
+	}
+
+	name, ok := values["name"]
+	switch {
+	case !ok:
+		l.report(node, "%s: name is required", where)
+	case isTemplated(name):
+	default:
+		if first, ok := names[name.Value]; ok {
+			l.report(name, "service %q is defined more than once (first at line %d)", name.Value, first.Line)
+		} else {
+			names[name.Value] = name
+		}
+		l.check(name, models.ValidateServiceName(name.Value))
+	}
+
+	serviceType, ok := values["type"]
+	typeValid := false
+	switch {
+	case !ok:
+		l.report(node, "%s: type is required", where)
+	case isTemplated(serviceType):
+	default:
+		err := l.validator.ValidateType(models.ServiceType(serviceType.Value))
+		l.check(serviceType, err)
+		typeValid = err == nil
+	}
+
+	if tier, ok := values["tier"]; ok && typeValid && !isTemplated(tier) {
+		l.check(tier, l.validator.ValidateTier(models.ServiceType(serviceType.Value), models.ServiceTier(tier.Value)))
+	}
+	if region, ok := values["region"]; ok && !isTemplated(region) {
+		l.check(region, models.ValidateRegion(region.Value))
+	}
+	if config, ok := values["config"]; ok && config.Tag != "!!null" {
+		l.mapping(config, where+".config", nil)
+	}
+	if labels, ok := values["labels"]; ok && labels.Tag != "!!null" {
+		l.lintLabels(labels, where+".labels")
+	}
+	if deps, ok := values["depends_on"]; ok && deps.Tag != "!!null" {
+		if deps.Kind != yaml.SequenceNode {
+			l.report(deps, "%s.depends_on must be a list of service names", where)
+		} else {
+			l.dependencies = append(l.dependencies, deps.Content...)
+		}
+	}
+}
+
+// lintLabels checks label keys and that label values are plain strings
+func (l *linter) lintLabels(node *yaml.Node, where string) {
+	keys, values := l.mapping(node, where, nil)
+	for _, key := range sortedKeys(keys) {
+		if !isTemplated(keys[key]) {
+			l.check(keys[key], models.ValidateLabelKey(key))
+		}
+		if value := values[key]; value.Kind != yaml.ScalarNode {
+			l.report(value, "label %q must have a string value", key)
+		}
+	}
+}
+
+// check records a validation error at a node
+func (l *linter) check(node *yaml.Node, err error) {
+	if err != nil {
+		l.report(node, "%s", err)
+		l.issues[len(l.issues)-1].Err = err
+	}
+}
+
+// isTemplated reports whether a value holds a ${...} reference
+func isTemplated(node *yaml.Node) bool {
+	return strings.Contains(node.Value, "${")
+}
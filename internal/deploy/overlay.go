@@ -0,0 +1,101 @@
+package deploy
+
+import (
+	"fmt"
+)
+
+// mergeDocuments deep-merges an overlay document onto a base document.
+// Services are matched by name: matching services are merged field by field
+// and new ones are appended. Maps are merged recursively, other values are
+// replaced, and a null value in the overlay removes the key.
+func mergeDocuments(base, overlay map[string]interface{}) (map[string]interface{}, error) {
+	rest := make(map[string]interface{}, len(overlay))
+	for key, value := range overlay {
+		if key != "services" {
+			rest[key] = value
+		}
+	}
+	merged := mergeMaps(base, rest)
+
+	if services, ok := overlay["services"]; ok {
+		list, err := mergeServices(merged["services"], services)
+		if err != nil {
+			return nil, err
+		}
+		merged["services"] = list
+	}
+	return merged, nil
+}
+
+// mergeServices merges overlay services onto the base services by name
+func mergeServices(base, overlay interface{}) ([]interface{}, error) {
+	baseList, err := serviceList(base)
+	if err != nil {
+		return nil, err
+	}
+	overlayList, err := serviceList(overlay)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(baseList))
+	for i, item := range baseList {
+		if name, ok := item.(map[string]interface{})["name"].(string); ok {
+			index[name] = i
+		}
+	}
+
+	for i, item := range overlayList {
+		svc := item.(map[string]interface{})
+		name, ok := svc["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("overlay services[%d]: name is required to match a service", i)
+		}
+		if j, ok := index[name]; ok {
+			baseList[j] = mergeMaps(baseList[j].(map[string]interface{}), svc)
+			continue
+		}
+		index[name] = len(baseList)
+		baseList = append(baseList, svc)
+	}
+
+	return baseList, nil
+}
+
+// serviceList checks that a services value is a list of mappings
+func serviceList(v interface{}) ([]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("services must be a list")
+	}
+	for i, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("services[%d] must be a mapping", i)
+		}
+	}
+	return list, nil
+}
+
+// mergeMaps merges overlay onto base recursively
+func mergeMaps(base, overlay map[string]interface{}) map[string]interface{} {
+	if base == nil {
+		base = make(map[string]interface{}, len(overlay))
+	}
+	for key, value := range overlay {
+		if value == nil {
+			delete(base, key)
+			continue
+		}
+		if overlayMap, ok := value.(map[string]interface{}); ok {
+			if baseMap, ok := base[key].(map[string]interface{}); ok {
+				base[key] = mergeMaps(baseMap, overlayMap)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
@@ -0,0 +1,254 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// Action is what a plan will do to a service
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+	ActionDelete    Action = "delete"
+)
+
+// Change is a single field that differs between the file and the live service.
+// Old is nil for added keys and New is nil for removed ones.
+type Change struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// ServicePlan is the planned action for one service
+type ServicePlan struct {
+	Name      string             `json:"name"`
+	Type      models.ServiceType `json:"type"`
+	Action    Action             `json:"action"`
+	ServiceID string             `json:"service_id,omitempty"`
+	Changes   []Change           `json:"changes,omitempty"`
+	// LiveLabels are the labels of the live service, if it exists
+	LiveLabels map[string]string `json:"-"`
+}
+
+// Plan compares a deployment file with the live services
+type Plan struct {
+	Services []ServicePlan `json:"services"`
+}
+
+// ManagedByLabel marks the services applied by qspin deploy, which are the
+// only ones --prune may delete
+const (
+	ManagedByLabel = ManagedLabelPrefix + "managed-by"
+	ManagedByValue = "qspin-deploy"
+)
+
+// PlanOptions controls how a plan is built
+type PlanOptions struct {
+	// Prune plans the deletion of live services that are not in the file.
+	// Only services labeled with ManagedByLabel are deleted, never preview
+	// copies or services created by other means.
+	Prune bool
+	// PruneAll, with Prune, plans the deletion of every live service that is
+	// not in the file, other than preview copies
+	PruneAll bool
+}
+
+// Manage returns a copy of a deployment whose services carry ManagedByLabel,
+// so that a later apply with --prune may delete them once removed from the file
+func Manage(cfg *models.DeploymentConfig) *models.DeploymentConfig {
+	managed := *cfg
+	managed.Services = make([]models.ServiceTemplate, len(cfg.Services))
+	for i, tmpl := range cfg.Services {
+		labels := make(map[string]string, len(tmpl.Labels)+1)
+		for k, v := range tmpl.Labels {
+			labels[k] = v
+		}
+		labels[ManagedByLabel] = ManagedByValue
+		managed.Services[i] = tmpl
+		managed.Services[i].Labels = labels
+	}
+	return &managed
+}
+
+// prunable reports whether --prune may delete a live service that is not in
+// the file. Preview copies belong to their preview and are never pruned.
+func prunable(svc models.Service, opts PlanOptions) bool {
+	if isPreview(svc.Labels) {
+		return false
+	}
+	return opts.PruneAll || svc.Labels[ManagedByLabel] == ManagedByValue
+}
+
+// Count returns the number of services with the given action
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, svc := range p.Services {
+		if svc.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// HasChanges reports whether applying the plan would change anything
+func (p *Plan) HasChanges() bool {
+	return p.Count(ActionUnchanged) != len(p.Services)
+}
+
+// Deletes returns the services the plan would delete
+func (p *Plan) Deletes() []ServicePlan {
+	var result []ServicePlan
+	for _, svc := range p.Services {
+		if svc.Action == ActionDelete {
+			result = append(result, svc)
+		}
+	}
+	return result
+}
+
+// BuildPlan compares each service in the file with the live service of the
+// same name. Config keys and labels only present on the live service are left
+// alone, since the server may set its own. Config values holding secret
+// references are redacted.
+func BuildPlan(cfg *models.DeploymentConfig, live []models.Service, opts PlanOptions) *Plan {
+	byName := make(map[string]*models.Service, len(live))
+	for i := range live {
+		byName[live[i].Name] = &live[i]
+	}
+
+	plan := &Plan{}
+	declared := make(map[string]bool, len(cfg.Services))
+	for _, tmpl := range cfg.Services {
+		declared[tmpl.Name] = true
+
+		svc, ok := byName[tmpl.Name]
+		if !ok {
+			plan.Services = append(plan.Services, ServicePlan{
+				Name:    tmpl.Name,
+				Type:    tmpl.Type,
+				Action:  ActionCreate,
+				Changes: creationChanges(tmpl),
+			})
+			continue
+		}
+
+		changes := diffService(tmpl, svc)
+		action := ActionUnchanged
+		if len(changes) > 0 {
+			action = ActionUpdate
+		}
+		plan.Services = append(plan.Services, ServicePlan{
+			Name:       tmpl.Name,
+			Type:       tmpl.Type,
+			Action:     action,
+			ServiceID:  svc.ID,
+			Changes:    changes,
+			LiveLabels: svc.Labels,
+		})
+	}
+
+	if opts.Prune {
+		var deletes []ServicePlan
+		for _, svc := range live {
+			if !declared[svc.Name] && prunable(svc, opts) {
+				deletes = append(deletes, ServicePlan{
+					Name:      svc.Name,
+					Type:      svc.Type,
+					Action:    ActionDelete,
+					ServiceID: svc.ID,
+				})
+			}
+		}
+		sort.Slice(deletes, func(i, j int) bool { return deletes[i].Name < deletes[j].Name })
+		plan.Services = append(plan.Services, deletes...)
+	}
+
+	return plan
+}
+
+// creationChanges lists the settings of a service that will be created
+func creationChanges(tmpl models.ServiceTemplate) []Change {
+	changes := []Change{{Field: "tier", New: string(tmpl.Tier)}}
+	if tmpl.Region != "" {
+		changes = append(changes, Change{Field: "region", New: tmpl.Region})
+	}
+	for _, key := range sortedKeys(tmpl.Config) {
+		changes = append(changes, Change{Field: "config." + key, New: redact(tmpl.Config[key])})
+	}
+	for _, key := range sortedKeys(tmpl.Labels) {
+		changes = append(changes, Change{Field: "labels." + key, New: tmpl.Labels[key]})
+	}
+	return changes
+}
+
+// diffService lists the fields of a live service that differ from the file
+func diffService(tmpl models.ServiceTemplate, svc *models.Service) []Change {
+	var changes []Change
+
+	if tmpl.Type != "" && tmpl.Type != svc.Type {
+		changes = append(changes, Change{Field: "type", Old: string(svc.Type), New: string(tmpl.Type)})
+	}
+	if tmpl.Tier != "" && tmpl.Tier != svc.Tier {
+		changes = append(changes, Change{Field: "tier", Old: string(svc.Tier), New: string(tmpl.Tier)})
+	}
+	if tmpl.Region != "" && tmpl.Region != svc.Region {
+		changes = append(changes, Change{Field: "region", Old: svc.Region, New: tmpl.Region})
+	}
+
+	for _, key := range sortedKeys(tmpl.Config) {
+		want := tmpl.Config[key]
+		have, ok := svc.Config[key]
+		if HasSecret(want) {
+			// Secrets are only resolved at apply time, so they cannot be
+			// compared; report them only when the key is missing
+			if !ok {
+				changes = append(changes, Change{Field: "config." + key, New: redact(want)})
+			}
+		} else if !ok {
+			changes = append(changes, Change{Field: "config." + key, New: want})
+		} else if !equalValues(have, want) {
+			changes = append(changes, Change{Field: "config." + key, Old: have, New: want})
+		}
+	}
+
+	for _, key := range sortedKeys(tmpl.Labels) {
+		want := tmpl.Labels[key]
+		have, ok := svc.Labels[key]
+		if !ok {
+			changes = append(changes, Change{Field: "labels." + key, New: want})
+		} else if have != want {
+			changes = append(changes, Change{Field: "labels." + key, Old: have, New: want})
+		}
+	}
+
+	return changes
+}
+
+// equalValues compares config values by their JSON encoding, so that numbers
+// decoded from YAML (int) and from the API (float64) compare equal
+func equalValues(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b) || fmt.Sprint(a) == fmt.Sprint(b)
+	}
+	return string(ja) == string(jb)
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
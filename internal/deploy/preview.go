@@ -0,0 +1,100 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// Labels that mark the services of a preview environment
+const (
+	// PreviewLabel holds the name of the preview a service belongs to
+	PreviewLabel = "preview"
+	// PreviewExpiresLabel holds the RFC 3339 time after which the preview may be deleted
+	PreviewExpiresLabel = "expires-at"
+)
+
+// PreviewServiceName returns the name of a service's copy in a preview
+func PreviewServiceName(service, preview string) string {
+	return service + "-" + preview
+}
+
+// Preview returns a copy of a deployment for a preview environment. Every
+// service is renamed with the preview name as a suffix, depends_on entries
+// are renamed to match, and each service is labeled with the preview name
+// and its expiry time.
+func Preview(cfg *models.DeploymentConfig, name string, expires time.Time) (*models.DeploymentConfig, error) {
+	if name == "" {
+		return nil, fmt.Errorf("preview name is required")
+	}
+
+	preview := *cfg
+	preview.Services = make([]models.ServiceTemplate, len(cfg.Services))
+	for i, tmpl := range cfg.Services {
+		tmpl.Name = PreviewServiceName(tmpl.Name, name)
+		if err := models.ValidateServiceName(tmpl.Name); err != nil {
+			return nil, fmt.Errorf("preview %q: %w", name, err)
+		}
+
+		if len(tmpl.DependsOn) > 0 {
+			deps := make([]string, len(tmpl.DependsOn))
+			for j, dep := range tmpl.DependsOn {
+				deps[j] = PreviewServiceName(dep, name)
+			}
+			tmpl.DependsOn = deps
+		}
+
+		labels := make(map[string]string, len(tmpl.Labels)+2)
+		for k, v := range tmpl.Labels {
+			labels[k] = v
+		}
+		labels[PreviewLabel] = name
+		labels[PreviewExpiresLabel] = expires.UTC().Format(time.RFC3339)
+		tmpl.Labels = labels
+
+		preview.Services[i] = tmpl
+	}
+
+	return &preview, nil
+}
+
+// PreviewServices returns the live services that belong to a preview, sorted
+// by name
+func PreviewServices(services []models.Service, name string) []models.Service {
+	var matched []models.Service
+	for _, svc := range services {
+		if svc.Labels[PreviewLabel] == name {
+			matched = append(matched, svc)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// PreviewExpiry returns when a preview service expires. It reports false for
+// services that are not part of a preview or have no readable expiry.
+func PreviewExpiry(svc models.Service) (time.Time, bool) {
+	if svc.Labels[PreviewLabel] == "" {
+		return time.Time{}, false
+	}
+	expires, err := time.Parse(time.RFC3339, svc.Labels[PreviewExpiresLabel])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+// ExpiredPreviews returns the preview services that expired before now,
+// sorted by name. Services without a readable expiry are never returned.
+func ExpiredPreviews(services []models.Service, now time.Time) []models.Service {
+	var expired []models.Service
+	for _, svc := range services {
+		if expires, ok := PreviewExpiry(svc); ok && expires.Before(now) {
+			expired = append(expired, svc)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Name < expired[j].Name })
+	return expired
+}
@@ -0,0 +1,185 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Plan output formats
+const (
+	PlanFormatText     = "text"
+	PlanFormatJSON     = "json"
+	PlanFormatMarkdown = "markdown"
+)
+
+// ANSI colors for text plans
+const (
+	colorReset  = "\033[0m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+	colorDim    = "\033[2m"
+)
+
+// actionSymbols are the diff markers used in text plans
+var actionSymbols = map[Action]string{
+	ActionCreate:    "+",
+	ActionUpdate:    "~",
+	ActionUnchanged: "=",
+	ActionDelete:    "-",
+}
+
+// actionColors are the colors of each action in text plans
+var actionColors = map[Action]string{
+	ActionCreate:    colorGreen,
+	ActionUpdate:    colorYellow,
+	ActionUnchanged: colorDim,
+	ActionDelete:    colorRed,
+}
+
+// Summary describes the plan totals in one line
+func (p *Plan) Summary() string {
+	return fmt.Sprintf("%d to create, %d to update, %d to delete, %d unchanged",
+		p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete), p.Count(ActionUnchanged))
+}
+
+// ParsePlanFormat checks a plan format name, accepting "md" for markdown
+func ParsePlanFormat(format string) (string, error) {
+	switch format {
+	case "", PlanFormatText:
+		return PlanFormatText, nil
+	case PlanFormatJSON:
+		return PlanFormatJSON, nil
+	case PlanFormatMarkdown, "md":
+		return PlanFormatMarkdown, nil
+	}
+	return "", fmt.Errorf("unknown plan format %q (expected text, json or markdown)", format)
+}
+
+// Render writes the plan in the given format: text, json or markdown
+func (p *Plan) Render(w io.Writer, format string, color bool) error {
+	format, err := ParsePlanFormat(format)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case PlanFormatJSON:
+		return p.RenderJSON(w)
+	case PlanFormatMarkdown:
+		p.RenderMarkdown(w)
+	default:
+		p.RenderText(w, color)
+	}
+	return nil
+}
+
+// RenderText writes the plan as a diff, optionally with ANSI colors
+func (p *Plan) RenderText(w io.Writer, color bool) {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	for _, svc := range p.Services {
+		c := actionColors[svc.Action]
+		fmt.Fprintf(w, "%s %s (%s)\n", paint(c, actionSymbols[svc.Action]), svc.Name, svc.Type)
+		for _, change := range svc.Changes {
+			fmt.Fprintf(w, "    %s\n", paint(changeColor(svc.Action, change), formatChange(svc.Action, change)))
+		}
+	}
+	if len(p.Services) > 0 {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Plan: %s.\n", p.Summary())
+}
+
+// RenderJSON writes the plan as indented JSON
+func (p *Plan) RenderJSON(w io.Writer) error {
+	out := struct {
+		*Plan
+		Summary map[Action]int `json:"summary"`
+	}{
+		Plan: p,
+		Summary: map[Action]int{
+			ActionCreate:    p.Count(ActionCreate),
+			ActionUpdate:    p.Count(ActionUpdate),
+			ActionDelete:    p.Count(ActionDelete),
+			ActionUnchanged: p.Count(ActionUnchanged),
+		},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// RenderMarkdown writes the plan as a Markdown table for PR comments
+func (p *Plan) RenderMarkdown(w io.Writer) {
+	fmt.Fprintln(w, "### QuickSpin deployment plan")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "**%s**\n", p.Summary())
+	if len(p.Services) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Service | Type | Action | Changes |")
+	fmt.Fprintln(w, "|---------|------|--------|---------|")
+	for _, svc := range p.Services {
+		var changes []string
+		for _, change := range svc.Changes {
+			changes = append(changes, "`"+markdownEscape(formatChange(svc.Action, change))+"`")
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n",
+			markdownEscape(svc.Name), svc.Type, svc.Action, strings.Join(changes, "<br>"))
+	}
+}
+
+// formatChange renders a change as "field: old -> new", marking added and
+// removed keys on updates
+func formatChange(action Action, c Change) string {
+	switch {
+	case action == ActionCreate:
+		return fmt.Sprintf("%s: %s", c.Field, formatValue(c.New))
+	case c.Old == nil:
+		return fmt.Sprintf("+ %s: %s", c.Field, formatValue(c.New))
+	case c.New == nil:
+		return fmt.Sprintf("- %s: %s", c.Field, formatValue(c.Old))
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Field, formatValue(c.Old), formatValue(c.New))
+}
+
+// changeColor colors added keys green and removed keys red within an update
+func changeColor(action Action, c Change) string {
+	switch {
+	case action != ActionUpdate:
+		return actionColors[action]
+	case c.Old == nil:
+		return colorGreen
+	case c.New == nil:
+		return colorRed
+	}
+	return colorYellow
+}
+
+// formatValue renders a config or label value for display
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []interface{}, map[string]interface{}:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// markdownEscape escapes characters that would break a Markdown table cell
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "`", "'", "\n", " ").Replace(s)
+}
@@ -0,0 +1,179 @@
+package deploy
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// SchemaDialect is the JSON Schema version of the generated schema
+const SchemaDialect = "http://json-schema.org/draft-07/schema#"
+
+var (
+	serviceTypeType = reflect.TypeOf(models.ServiceType(""))
+	serviceTierType = reflect.TypeOf(models.ServiceTier(""))
+)
+
+// fieldDescriptions are shown by editors for each key of a deployment file
+var fieldDescriptions = map[string]string{
+	"version":      "Deployment file format version",
+	"organization": "Organization to deploy to (defaults to the configured organization)",
+	"services":     "Services to deploy",
+	"name":         "Service name, unique within the file (lowercase letters, digits and hyphens)",
+	"type":         "Service type",
+	"tier":         "Pricing tier (defaults to developer)",
+	"region":       "Region to deploy to, e.g. us-east-1",
+	"config":       "Service-specific settings",
+	"labels":       "Labels to attach to the service",
+	"depends_on":   "Services that must be running before this one is applied",
+}
+
+// yamlField is a struct field as it appears in a deployment file
+type yamlField struct {
+	Name string
+	Type reflect.Type
+}
+
+// yamlFields lists the keys of a struct as named by its yaml tags
+func yamlFields(t reflect.Type) []yamlField {
+	var fields []yamlField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, yamlField{Name: name, Type: f.Type})
+	}
+	return fields
+}
+
+// fieldNames returns the yaml keys of a struct
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for _, f := range yamlFields(t) {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// Schema returns a JSON Schema for deployment files, generated from
+// models.DeploymentConfig. Service types and tiers are taken from the catalog
+// when one is given, and from the built-in lists otherwise.
+func Schema(catalog []models.ServiceTypeInfo) map[string]interface{} {
+	validator := models.NewValidator(catalog)
+	types := validator.Types()
+
+	var tiers []string
+	for _, serviceType := range types {
+		for _, tier := range validator.Tiers(models.ServiceType(serviceType)) {
+			if !containsString(tiers, tier) {
+				tiers = append(tiers, tier)
+			}
+		}
+	}
+
+	g := &schemaGenerator{types: types, tiers: tiers}
+	schema := g.schemaFor(reflect.TypeOf(models.DeploymentConfig{}))
+	schema["$schema"] = SchemaDialect
+	schema["title"] = "QuickSpin deployment file"
+	schema["required"] = []string{"services"}
+
+	props := schema["properties"].(map[string]interface{})
+	props["version"].(map[string]interface{})["enum"] = []string{SupportedVersion}
+	props["services"].(map[string]interface{})["minItems"] = 1
+
+	service := props["services"].(map[string]interface{})["items"].(map[string]interface{})
+	service["required"] = []string{"name", "type"}
+	serviceProps := service["properties"].(map[string]interface{})
+	serviceProps["name"].(map[string]interface{})["pattern"] = models.ServiceNamePattern
+	serviceProps["name"].(map[string]interface{})["maxLength"] = models.MaxServiceNameLength
+	serviceProps["region"].(map[string]interface{})["pattern"] = models.RegionPattern
+	serviceProps["labels"].(map[string]interface{})["propertyNames"] = map[string]interface{}{
+		"pattern": models.LabelKeyPattern,
+	}
+
+	// Restrict tiers per type when the catalog offers different ones
+	if len(catalog) > 0 {
+		var rules []interface{}
+		for _, serviceType := range types {
+			rules = append(rules, map[string]interface{}{
+				"if": map[string]interface{}{
+					"properties": map[string]interface{}{"type": map[string]interface{}{"const": serviceType}},
+				},
+				"then": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"tier": map[string]interface{}{"enum": validator.Tiers(models.ServiceType(serviceType))},
+					},
+				},
+			})
+		}
+		service["allOf"] = rules
+	}
+
+	return schema
+}
+
+// schemaGenerator builds JSON Schema fragments from Go types
+type schemaGenerator struct {
+	types []string
+	tiers []string
+}
+
+// schemaFor returns the schema of a Go type as it appears in YAML
+func (g *schemaGenerator) schemaFor(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == serviceTypeType:
+		return map[string]interface{}{"type": "string", "enum": g.types}
+	case t == serviceTierType:
+		return map[string]interface{}{"type": "string", "enum": g.tiers}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		schema := map[string]interface{}{"type": "object"}
+		if t.Elem().Kind() != reflect.Interface {
+			schema["additionalProperties"] = g.schemaFor(t.Elem())
+		}
+		return schema
+	case reflect.Struct:
+		props := make(map[string]interface{})
+		for _, f := range yamlFields(t) {
+			prop := g.schemaFor(f.Type)
+			if desc, ok := fieldDescriptions[f.Name]; ok {
+				prop["description"] = desc
+			}
+			props[f.Name] = prop
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"additionalProperties": false,
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// containsString reports whether s is in values
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// Secret reference sources
+const (
+	SecretSourceFile = "file"
+	SecretSourceEnv  = "env"
+	SecretSourceExec = "exec"
+)
+
+// secretPattern matches ${file:path}, ${env:NAME} and ${exec:command}
+var secretPattern = regexp.MustCompile(`\$\{(file|env|exec):([^}]*)\}`)
+
+// secretExecTimeout bounds how long an ${exec:...} command may run
+const secretExecTimeout = 30 * time.Second
+
+// SecretRef is a reference to a secret kept outside the deployment file
+type SecretRef struct {
+	Source string
+	Target string
+}
+
+func (r SecretRef) String() string {
+	return fmt.Sprintf("${%s:%s}", r.Source, r.Target)
+}
+
+// Redacted stands in for a config value that holds a secret reference when
+// plans and diffs are shown
+type Redacted struct {
+	Source string
+}
+
+func (r Redacted) String() string {
+	return fmt.Sprintf("(secret from %s)", r.Source)
+}
+
+// MarshalJSON encodes the placeholder rather than the value
+func (r Redacted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// SecretRefs returns the secret references in a config value, including
+// those nested in lists and maps
+func SecretRefs(v interface{}) []SecretRef {
+	var refs []SecretRef
+	switch v := v.(type) {
+	case string:
+		for _, m := range secretPattern.FindAllStringSubmatch(v, -1) {
+			refs = append(refs, SecretRef{Source: m[1], Target: m[2]})
+		}
+	case []interface{}:
+		for _, item := range v {
+			refs = append(refs, SecretRefs(item)...)
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			refs = append(refs, SecretRefs(v[key])...)
+		}
+	}
+	return refs
+}
+
+// HasSecret reports whether a config value holds a secret reference
+func HasSecret(v interface{}) bool {
+	return len(SecretRefs(v)) > 0
+}
+
+// ExecRefs returns the ${exec:...} references in a deployment's service config
+func ExecRefs(cfg *models.DeploymentConfig) []SecretRef {
+	var refs []SecretRef
+	for _, svc := range cfg.Services {
+		for _, ref := range SecretRefs(svc.Config) {
+			if ref.Source == SecretSourceExec {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// redact replaces a value holding a secret reference with a placeholder
+func redact(v interface{}) interface{} {
+	if refs := SecretRefs(v); len(refs) > 0 {
+		return Redacted{Source: refs[0].Source}
+	}
+	return v
+}
+
+// SecretResolver replaces secret references with their values at apply time
+type SecretResolver struct {
+	// BaseDir is the directory relative ${file:...} paths are read from
+	BaseDir string
+	// LookupEnv resolves ${env:...} references
+	LookupEnv func(name string) (string, bool)
+	// Exec runs ${exec:...} commands and returns their output. When nil,
+	// ${exec:...} references fail to resolve.
+	Exec func(ctx context.Context, command string) (string, error)
+
+	cache map[SecretRef]string
+}
+
+// NewSecretResolver creates a resolver that reads files relative to baseDir
+// and the environment. Shell commands are only run when allowExec is set,
+// since they come straight from the deployment file.
+func NewSecretResolver(baseDir string, allowExec bool) *SecretResolver {
+	r := &SecretResolver{
+		BaseDir:   baseDir,
+		LookupEnv: os.LookupEnv,
+	}
+	if allowExec {
+		r.Exec = runSecretCommand
+	}
+	return r
+}
+
+// Resolve returns a copy of the deployment with every secret reference in the
+// service config replaced by its value. The original is left untouched.
+func (r *SecretResolver) Resolve(ctx context.Context, cfg *models.DeploymentConfig) (*models.DeploymentConfig, error) {
+	resolved := *cfg
+	resolved.Services = make([]models.ServiceTemplate, len(cfg.Services))
+
+	for i, svc := range cfg.Services {
+		if svc.Config != nil {
+			config := make(map[string]interface{}, len(svc.Config))
+			for _, key := range sortedKeys(svc.Config) {
+				value, err := r.resolveValue(ctx, svc.Config[key])
+				if err != nil {
+					return nil, fmt.Errorf("service %q: config.%s: %w", svc.Name, key, err)
+				}
+				config[key] = value
+			}
+			svc.Config = config
+		}
+		resolved.Services[i] = svc
+	}
+
+	return &resolved, nil
+}
+
+// resolveValue resolves the references in a value, recursing into lists and maps
+func (r *SecretResolver) resolveValue(ctx context.Context, v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		var resolveErr error
+		result := secretPattern.ReplaceAllStringFunc(v, func(match string) string {
+			m := secretPattern.FindStringSubmatch(match)
+			value, err := r.resolveRef(ctx, SecretRef{Source: m[1], Target: m[2]})
+			if err != nil && resolveErr == nil {
+				resolveErr = err
+			}
+			return value
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		return result, nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			value, err := r.resolveValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			value, err := r.resolveValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	}
+	return v, nil
+}
+
+// resolveRef reads the value of one reference, running each command once
+func (r *SecretResolver) resolveRef(ctx context.Context, ref SecretRef) (string, error) {
+	if value, ok := r.cache[ref]; ok {
+		return value, nil
+	}
+	if ref.Target == "" {
+		return "", fmt.Errorf("%s: empty reference", ref)
+	}
+
+	var value string
+	switch ref.Source {
+	case SecretSourceFile:
+		path := ref.Target
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.BaseDir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", ref, err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	case SecretSourceEnv:
+		v, ok := r.LookupEnv(ref.Target)
+		if !ok {
+			return "", fmt.Errorf("%s: environment variable %s is not set", ref, ref.Target)
+		}
+		value = v
+	case SecretSourceExec:
+		if r.Exec == nil {
+			return "", fmt.Errorf("%s: running commands is not allowed", ref)
+		}
+		out, err := r.Exec(ctx, ref.Target)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", ref, err)
+		}
+		value = strings.TrimRight(out, "\r\n")
+	default:
+		return "", fmt.Errorf("%s: unknown secret source %q", ref, ref.Source)
+	}
+
+	if r.cache == nil {
+		r.cache = make(map[SecretRef]string)
+	}
+	r.cache[ref] = value
+	return value, nil
+}
+
+// runSecretCommand runs a command through the shell and returns its stdout
+func runSecretCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, secretExecTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -2,27 +2,37 @@ package models
 
 // DeploymentConfig represents a deployment configuration file
 type DeploymentConfig struct {
-	Version      string            `yaml:"version"`
-	Organization string            `yaml:"organization"`
-	Services     []ServiceTemplate `yaml:"services"`
+	Version      string            `yaml:"version" json:"version"`
+	Organization string            `yaml:"organization,omitempty" json:"organization"`
+	Services     []ServiceTemplate `yaml:"services" json:"services"`
 }
 
 // ServiceTemplate represents a service definition in deployment config
 type ServiceTemplate struct {
-	Name   string                 `yaml:"name"`
-	Type   ServiceType            `yaml:"type"`
-	Tier   ServiceTier            `yaml:"tier"`
-	Region string                 `yaml:"region"`
-	Config map[string]interface{} `yaml:"config,omitempty"`
-	Labels map[string]string      `yaml:"labels,omitempty"`
+	Name   string                 `yaml:"name" json:"name"`
+	Type   ServiceType            `yaml:"type" json:"type"`
+	Tier   ServiceTier            `yaml:"tier,omitempty" json:"tier"`
+	Region string                 `yaml:"region,omitempty" json:"region,omitempty"`
+	Config map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	Labels map[string]string      `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// DependsOn names services that must be running before this one is applied
+	DependsOn []string `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
 }
 
 // DeploymentResult represents the result of a deployment operation
 type DeploymentResult struct {
+	ID              string            `json:"id,omitempty"`
+	Status          string            `json:"status,omitempty"`
 	Success         bool              `json:"success"`
 	ServicesCreated []string          `json:"services_created,omitempty"`
 	ServicesFailed  []DeploymentError `json:"services_failed,omitempty"`
+	ServicesDeleted []string          `json:"services_deleted,omitempty"`
 	Message         string            `json:"message"`
+	CreatedAt       Time              `json:"created_at"`
+	// CreatedBy is the user or API key that started the deployment
+	CreatedBy string `json:"created_by,omitempty"`
+	// Config is the deployment file as applied, when the API returns it
+	Config *DeploymentConfig `json:"config,omitempty"`
 }
 
 // DeploymentError represents an error during deployment
@@ -24,6 +24,37 @@ const (
 	ServiceTierEnterprise ServiceTier = "enterprise"
 )
 
+// ServiceTiers lists all tiers from smallest to largest
+var ServiceTiers = []ServiceTier{
+	ServiceTierStarter,
+	ServiceTierDeveloper,
+	ServiceTierBasic,
+	ServiceTierStandard,
+	ServiceTierPro,
+	ServiceTierPremium,
+	ServiceTierEnterprise,
+}
+
+// Rank returns the position of the tier in ServiceTiers, or -1 for unknown tiers
+func (t ServiceTier) Rank() int {
+	for i, tier := range ServiceTiers {
+		if tier == t {
+			return i
+		}
+	}
+	return -1
+}
+
+// SupportsReplicas reports whether the service type can run more than one
+// replica. PostgreSQL and MySQL run as a single node.
+func (t ServiceType) SupportsReplicas() bool {
+	switch t {
+	case ServiceTypeRedis, ServiceTypeRabbitMQ, ServiceTypeMongoDB, ServiceTypeElasticsearch:
+		return true
+	}
+	return false
+}
+
 // ServiceStatus represents the current status of a service
 type ServiceStatus string
 
@@ -43,6 +74,7 @@ type Service struct {
 	Tier           ServiceTier            `json:"tier"`
 	Status         ServiceStatus          `json:"status"`
 	Region         string                 `json:"region"`
+	Replicas       int                    `json:"replicas,omitempty"`
 	OrganizationID string                 `json:"organization_id"`
 	Config         map[string]interface{} `json:"config,omitempty"`
 	Labels         map[string]string      `json:"labels,omitempty"`
@@ -53,6 +85,14 @@ type Service struct {
 	Namespace      string                 `json:"namespace,omitempty"`
 }
 
+// ReplicaCount returns the number of replicas, treating an unreported count as one
+func (s *Service) ReplicaCount() int {
+	if s.Replicas < 1 {
+		return 1
+	}
+	return s.Replicas
+}
+
 // ServiceCredentials holds connection information for a service
 type ServiceCredentials struct {
 	Host     string            `json:"host"`
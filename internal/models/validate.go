@@ -0,0 +1,283 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ServiceTypes lists all known service types
+var ServiceTypes = []ServiceType{
+	ServiceTypeRedis,
+	ServiceTypeRabbitMQ,
+	ServiceTypeElasticsearch,
+	ServiceTypePostgreSQL,
+	ServiceTypeMongoDB,
+	ServiceTypeMySQL,
+}
+
+// MaxServiceNameLength is the longest allowed service name (a DNS label)
+const MaxServiceNameLength = 63
+
+// MaxLabelNameLength is the longest allowed label name, not counting its prefix
+const MaxLabelNameLength = 63
+
+// Patterns for service names, regions and label keys, shared with the
+// deployment file schema
+const (
+	ServiceNamePattern = `^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	RegionPattern      = `^[a-z][a-z0-9]*(-[a-z0-9]+)*$`
+	LabelKeyPattern    = `^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+)
+
+var (
+	serviceNamePattern = regexp.MustCompile(ServiceNamePattern)
+	regionPattern      = regexp.MustCompile(RegionPattern)
+	labelKeyPattern    = regexp.MustCompile(LabelKeyPattern)
+)
+
+// ValidationError describes an invalid field value
+type ValidationError struct {
+	Field      string
+	Value      string
+	Message    string
+	Suggestion string
+}
+
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("invalid %s %q: %s", e.Field, e.Value, e.Message)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s?)", e.Suggestion)
+	}
+	return msg
+}
+
+// Validator checks service fields against the known enums, or against a
+// server-provided catalog when one is set
+type Validator struct {
+	catalog []ServiceTypeInfo
+}
+
+// NewValidator creates a validator. A nil or empty catalog falls back to the
+// built-in service types and tiers.
+func NewValidator(catalog []ServiceTypeInfo) *Validator {
+	return &Validator{catalog: catalog}
+}
+
+// Types returns the valid service types
+func (v *Validator) Types() []string {
+	var names []string
+	if len(v.catalog) > 0 {
+		for _, info := range v.catalog {
+			names = append(names, string(info.Type))
+		}
+		return names
+	}
+	for _, t := range ServiceTypes {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+// Tiers returns the valid tiers for a service type
+func (v *Validator) Tiers(serviceType ServiceType) []string {
+	var names []string
+	for _, info := range v.catalog {
+		if info.Type == serviceType && len(info.Tiers) > 0 {
+			for _, tier := range info.Tiers {
+				names = append(names, string(tier.Tier))
+			}
+			return names
+		}
+	}
+	for _, t := range ServiceTiers {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+// ValidateType checks that a service type is known
+func (v *Validator) ValidateType(serviceType ServiceType) error {
+	valid := v.Types()
+	if containsValue(valid, string(serviceType)) {
+		return nil
+	}
+	return &ValidationError{
+		Field:      "service type",
+		Value:      string(serviceType),
+		Message:    "must be one of " + strings.Join(valid, ", "),
+		Suggestion: Suggest(string(serviceType), valid),
+	}
+}
+
+// ValidateTier checks that a tier is offered for a service type
+func (v *Validator) ValidateTier(serviceType ServiceType, tier ServiceTier) error {
+	valid := v.Tiers(serviceType)
+	if containsValue(valid, string(tier)) {
+		return nil
+	}
+	return &ValidationError{
+		Field:      "tier",
+		Value:      string(tier),
+		Message:    "must be one of " + strings.Join(valid, ", "),
+		Suggestion: Suggest(string(tier), valid),
+	}
+}
+
+// ValidateReplicas checks that a replica count is positive and, above one,
+// supported by the service type
+func ValidateReplicas(serviceType ServiceType, replicas int) error {
+	if replicas < 1 {
+		return &ValidationError{Field: "replica count", Value: fmt.Sprint(replicas), Message: "must be at least 1"}
+	}
+	if replicas > 1 && serviceType != "" && !serviceType.SupportsReplicas() {
+		return &ValidationError{
+			Field:   "replica count",
+			Value:   fmt.Sprint(replicas),
+			Message: fmt.Sprintf("%s services run as a single node and do not support replicas", serviceType),
+		}
+	}
+	return nil
+}
+
+// ValidateServiceName checks that a name is a valid DNS label: lowercase
+// letters, digits and hyphens, starting with a letter and not ending with a
+// hyphen, at most 63 characters
+func ValidateServiceName(name string) error {
+	invalid := func(message string) error {
+		return &ValidationError{Field: "service name", Value: name, Message: message}
+	}
+
+	switch {
+	case name == "":
+		return invalid("must not be empty")
+	case len(name) > MaxServiceNameLength:
+		return invalid(fmt.Sprintf("must be at most %d characters", MaxServiceNameLength))
+	case serviceNamePattern.MatchString(name):
+		return nil
+	case serviceNamePattern.MatchString(strings.ToLower(name)):
+		err := invalid("must be lowercase").(*ValidationError)
+		err.Suggestion = strings.ToLower(name)
+		return err
+	case name[0] < 'a' || name[0] > 'z':
+		return invalid("must start with a lowercase letter")
+	case strings.HasSuffix(name, "-"):
+		return invalid("must not end with a hyphen")
+	default:
+		return invalid("may only contain lowercase letters, digits and hyphens")
+	}
+}
+
+// ValidateRegion checks that a region looks like a region identifier such as
+// us-east-1, europe-west1 or eastus.
+// An empty region is valid and means the default region.
+func ValidateRegion(region string) error {
+	if region == "" || regionPattern.MatchString(region) {
+		return nil
+	}
+	return &ValidationError{
+		Field:   "region",
+		Value:   region,
+		Message: "must be a region identifier such as us-east-1 or eastus",
+	}
+}
+
+// ValidateLabelKey checks that a label key is a name of letters, digits, '-',
+// '_' and '.' with an optional DNS prefix, e.g. team or quickspin.io/git-commit
+func ValidateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		name = key[i+1:]
+	}
+
+	switch {
+	case key == "":
+		return &ValidationError{Field: "label key", Value: key, Message: "must not be empty"}
+	case len(name) > MaxLabelNameLength:
+		return &ValidationError{Field: "label key", Value: key, Message: fmt.Sprintf("name must be at most %d characters", MaxLabelNameLength)}
+	case !labelKeyPattern.MatchString(key):
+		return &ValidationError{
+			Field:   "label key",
+			Value:   key,
+			Message: "must be letters, digits, '-', '_' or '.' with an optional DNS prefix such as example.com/",
+		}
+	}
+	return nil
+}
+
+// ValidateService checks the name, type, tier and region of a service definition
+func (v *Validator) ValidateService(name string, serviceType ServiceType, tier ServiceTier, region string) error {
+	if err := ValidateServiceName(name); err != nil {
+		return err
+	}
+	if err := v.ValidateType(serviceType); err != nil {
+		return err
+	}
+	if err := v.ValidateTier(serviceType, tier); err != nil {
+		return err
+	}
+	return ValidateRegion(region)
+}
+
+// Suggest returns the candidate closest to input, or "" if none is close
+// enough to be a likely typo
+func Suggest(input string, candidates []string) string {
+	input = strings.ToLower(input)
+	if input == "" {
+		return ""
+	}
+
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		d := levenshtein(input, strings.ToLower(candidate))
+		if strings.HasPrefix(strings.ToLower(candidate), input) {
+			d = 0
+		}
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	// Allow roughly one edit per three characters, and at least two
+	threshold := len(input) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+	if bestDistance < 0 || bestDistance > threshold {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// containsValue reports whether values contains s exactly
+func containsValue(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
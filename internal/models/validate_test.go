@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateType(t *testing.T) {
+	v := NewValidator(nil)
+	assert.NoError(t, v.ValidateType(ServiceTypePostgreSQL))
+
+	err := v.ValidateType("postgress")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean postgresql?")
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, "postgresql", verr.Suggestion)
+
+	err = v.ValidateType("cassandra")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "did you mean")
+}
+
+func TestValidateTierWithCatalog(t *testing.T) {
+	v := NewValidator([]ServiceTypeInfo{
+		{Type: ServiceTypeRedis, Tiers: []TierInfo{{Tier: ServiceTierStarter}, {Tier: ServiceTierPro}}},
+	})
+
+	assert.NoError(t, v.ValidateTier(ServiceTypeRedis, ServiceTierPro))
+	assert.Error(t, v.ValidateTier(ServiceTypeRedis, ServiceTierEnterprise))
+	assert.Error(t, v.ValidateType(ServiceTypeMySQL))
+
+	err := v.ValidateTier(ServiceTypeRedis, "starer")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean starter?")
+
+	// Types without catalog tiers fall back to the built-in list
+	assert.NoError(t, NewValidator(nil).ValidateTier(ServiceTypeMySQL, ServiceTierEnterprise))
+}
+
+func TestValidateServiceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{"cache-primary", ""},
+		{"db1", ""},
+		{"a", ""},
+		{"", "must not be empty"},
+		{"My-Cache", "did you mean my-cache?"},
+		{"1cache", "must start with a lowercase letter"},
+		{"cache-", "must not end with a hyphen"},
+		{"cache_primary", "may only contain"},
+		{"a123456789012345678901234567890123456789012345678901234567890123", "at most 63"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServiceName(tt.name)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateRegion(t *testing.T) {
+	assert.NoError(t, ValidateRegion(""))
+	assert.NoError(t, ValidateRegion("us-east-1"))
+	assert.NoError(t, ValidateRegion("eu-west-2"))
+	assert.NoError(t, ValidateRegion("europe-west1"))
+	assert.NoError(t, ValidateRegion("eastus"))
+	assert.NoError(t, ValidateRegion("westeurope"))
+	assert.Error(t, ValidateRegion("US East"))
+	assert.Error(t, ValidateRegion("us_east_1"))
+	assert.Error(t, ValidateRegion("us-east-"))
+}
+
+func TestValidateLabelKey(t *testing.T) {
+	for _, key := range []string{"team", "env", "app.kubernetes.io/name", "quickspin.io/git-commit", "Tier_2"} {
+		assert.NoError(t, ValidateLabelKey(key), key)
+	}
+	for _, key := range []string{"", "-team", "team-", "has space", "Example.com/x", "a/b/c", "quickspin.io/", strings.Repeat("a", 64)} {
+		assert.Error(t, ValidateLabelKey(key), key)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"redis", "rabbitmq", "postgresql", "mongodb", "mysql"}
+	assert.Equal(t, "redis", Suggest("reddis", candidates))
+	assert.Equal(t, "mongodb", Suggest("mongo", candidates))
+	assert.Equal(t, "mysql", Suggest("MySQL", candidates))
+	assert.Equal(t, "", Suggest("oracle", candidates))
+	assert.Equal(t, "", Suggest("", candidates))
+}
+
+func TestValidateReplicas(t *testing.T) {
+	assert.NoError(t, ValidateReplicas(ServiceTypeRedis, 3))
+	assert.NoError(t, ValidateReplicas(ServiceTypePostgreSQL, 1))
+	assert.ErrorContains(t, ValidateReplicas(ServiceTypePostgreSQL, 2), "single node")
+	assert.ErrorContains(t, ValidateReplicas(ServiceTypeRedis, 0), "at least 1")
+	assert.NoError(t, ValidateReplicas("", 5))
+}
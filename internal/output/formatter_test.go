@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -132,3 +133,28 @@ func TestInfo(t *testing.T) {
 	})
 	assert.Equal(t, "Test info message\n", output)
 }
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil, 0, 100))
+	assert.Equal(t, "▁▄█", Sparkline([]float64{0, 50, 100}, 0, 100))
+	assert.Equal(t, "▁█", Sparkline([]float64{10, 20}, 0, 0))
+	assert.Equal(t, "▁▁", Sparkline([]float64{5, 5}, 0, 0))
+	assert.Equal(t, "█", Sparkline([]float64{150}, 0, 100))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "2.0 MiB", FormatBytes(2*1024*1024))
+}
+
+func TestConfirm(t *testing.T) {
+	original := ConfirmInput
+	defer func() { ConfirmInput = original }()
+
+	ConfirmInput = strings.NewReader("yes\n")
+	assert.True(t, Confirm("Proceed?"))
+
+	ConfirmInput = strings.NewReader("y\n")
+	assert.False(t, Confirm("Proceed?"))
+}
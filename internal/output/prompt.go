@@ -0,0 +1,19 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// ConfirmInput is where confirmation answers are read from
+var ConfirmInput io.Reader = os.Stdin
+
+// Confirm prints a message and reports whether the user typed 'yes'
+func Confirm(message string) bool {
+	fmt.Println(message)
+	fmt.Print("Type 'yes' to confirm: ")
+	var confirmation string
+	_, _ = fmt.Fscanln(ConfirmInput, &confirmation)
+	return confirmation == "yes"
+}
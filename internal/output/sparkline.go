@@ -0,0 +1,72 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sparkTicks are the block characters used to draw sparklines, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single-line chart scaled between min and max.
+// If max <= min the range is taken from the values themselves.
+func Sparkline(values []float64, min, max float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	if max <= min {
+		min, max = values[0], values[0]
+		for _, v := range values {
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > min {
+			idx = int((v - min) / (max - min) * float64(len(sparkTicks)-1))
+		}
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(sparkTicks) {
+			idx = len(sparkTicks) - 1
+		}
+		b.WriteRune(sparkTicks[idx])
+	}
+	return b.String()
+}
+
+// FormatBytes formats a byte count using binary units (KiB, MiB, ...)
+func FormatBytes(n float64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%.0f B", n)
+	}
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := -1
+	for n >= unit && i < len(units)-1 {
+		n /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
+
+// PercentBar renders a 0-100 percentage as a fixed-width bar
+func PercentBar(percent float64, width int) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	filled := int(percent / 100 * float64(width))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
@@ -0,0 +1,254 @@
+// Package service holds the service helpers shared by the service and deploy
+// commands: waiting for a service to settle and turning its credentials into
+// connection URIs and environment variables.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+// Connection output formats
+const (
+	ConnectFormatText   = "text"
+	ConnectFormatEnv    = "env"
+	ConnectFormatDotenv = "dotenv"
+	ConnectFormatURI    = "uri"
+	ConnectFormatJSON   = "json"
+)
+
+// EnvVar is a single environment variable derived from service credentials
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// envNames holds the variable names used for a service type
+type envNames struct {
+	URL      string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+	Extra    string
+}
+
+// serviceEnvNames maps each service type to the variable names its clients conventionally read
+var serviceEnvNames = map[models.ServiceType]envNames{
+	models.ServiceTypeRedis: {
+		URL: "REDIS_URL", Host: "REDIS_HOST", Port: "REDIS_PORT",
+		User: "REDIS_USERNAME", Password: "REDIS_PASSWORD", Database: "REDIS_DB", Extra: "REDIS_",
+	},
+	models.ServiceTypeRabbitMQ: {
+		URL: "AMQP_URL", Host: "RABBITMQ_HOST", Port: "RABBITMQ_PORT",
+		User: "RABBITMQ_USER", Password: "RABBITMQ_PASSWORD", Database: "RABBITMQ_VHOST", Extra: "RABBITMQ_",
+	},
+	models.ServiceTypePostgreSQL: {
+		URL: "DATABASE_URL", Host: "PGHOST", Port: "PGPORT",
+		User: "PGUSER", Password: "PGPASSWORD", Database: "PGDATABASE", Extra: "PG",
+	},
+	models.ServiceTypeMySQL: {
+		URL: "DATABASE_URL", Host: "MYSQL_HOST", Port: "MYSQL_PORT",
+		User: "MYSQL_USER", Password: "MYSQL_PASSWORD", Database: "MYSQL_DATABASE", Extra: "MYSQL_",
+	},
+	models.ServiceTypeMongoDB: {
+		URL: "MONGODB_URI", Host: "MONGODB_HOST", Port: "MONGODB_PORT",
+		User: "MONGODB_USERNAME", Password: "MONGODB_PASSWORD", Database: "MONGODB_DATABASE", Extra: "MONGODB_",
+	},
+	models.ServiceTypeElasticsearch: {
+		URL: "ELASTICSEARCH_URL", Host: "ELASTICSEARCH_HOST", Port: "ELASTICSEARCH_PORT",
+		User: "ELASTICSEARCH_USERNAME", Password: "ELASTICSEARCH_PASSWORD", Database: "ELASTICSEARCH_INDEX", Extra: "ELASTICSEARCH_",
+	},
+}
+
+// uriSchemes maps each service type to the scheme used when the API does not return a URI
+var uriSchemes = map[models.ServiceType]string{
+	models.ServiceTypeRedis:         "redis",
+	models.ServiceTypeRabbitMQ:      "amqp",
+	models.ServiceTypePostgreSQL:    "postgresql",
+	models.ServiceTypeMySQL:         "mysql",
+	models.ServiceTypeMongoDB:       "mongodb",
+	models.ServiceTypeElasticsearch: "https",
+}
+
+// RenderConnection renders the credentials of a service in the given format
+func RenderConnection(service *models.Service, format, prefix string) (string, error) {
+	creds := service.Credentials
+	if creds == nil {
+		return "", fmt.Errorf("service '%s' has no connection credentials", service.Name)
+	}
+
+	vars := CredentialEnv(service.Type, creds, prefix)
+
+	var b strings.Builder
+	switch format {
+	case ConnectFormatEnv:
+		for _, v := range vars {
+			fmt.Fprintf(&b, "export %s=%s\n", v.Name, shellQuote(v.Value))
+		}
+	case ConnectFormatDotenv:
+		for _, v := range vars {
+			fmt.Fprintf(&b, "%s=%s\n", v.Name, DotenvQuote(v.Value))
+		}
+	case ConnectFormatURI:
+		b.WriteString(ConnectionURI(service.Type, creds))
+		b.WriteString("\n")
+	case ConnectFormatJSON:
+		payload := struct {
+			Service     string                     `json:"service"`
+			Type        models.ServiceType         `json:"type"`
+			URI         string                     `json:"uri"`
+			Credentials *models.ServiceCredentials `json:"credentials"`
+			Env         map[string]string          `json:"env"`
+		}{
+			Service:     service.Name,
+			Type:        service.Type,
+			URI:         ConnectionURI(service.Type, creds),
+			Credentials: creds,
+			Env:         make(map[string]string, len(vars)),
+		}
+		for _, v := range vars {
+			payload.Env[v.Name] = v.Value
+		}
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode credentials: %w", err)
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	case ConnectFormatText, "":
+		fmt.Fprintf(&b, "%-12s %s (%s)\n", "Service:", service.Name, service.Type)
+		fmt.Fprintf(&b, "%-12s %s\n", "Host:", creds.Host)
+		fmt.Fprintf(&b, "%-12s %d\n", "Port:", creds.Port)
+		if creds.Username != "" {
+			fmt.Fprintf(&b, "%-12s %s\n", "Username:", creds.Username)
+		}
+		if creds.Password != "" {
+			fmt.Fprintf(&b, "%-12s %s\n", "Password:", creds.Password)
+		}
+		if creds.Database != "" {
+			fmt.Fprintf(&b, "%-12s %s\n", "Database:", creds.Database)
+		}
+		fmt.Fprintf(&b, "%-12s %s\n", "URI:", ConnectionURI(service.Type, creds))
+	default:
+		return "", fmt.Errorf("invalid format %q", format)
+	}
+
+	return b.String(), nil
+}
+
+// CredentialEnv returns the environment variables for a service's credentials.
+// Variable names follow the conventions of each service type's client libraries
+// (REDIS_URL, DATABASE_URL, AMQP_URL, ...) and are prepended with prefix.
+func CredentialEnv(serviceType models.ServiceType, creds *models.ServiceCredentials, prefix string) []EnvVar {
+	names, ok := serviceEnvNames[serviceType]
+	if !ok {
+		upper := envKey(string(serviceType))
+		names = envNames{
+			URL: upper + "_URL", Host: upper + "_HOST", Port: upper + "_PORT",
+			User: upper + "_USERNAME", Password: upper + "_PASSWORD", Database: upper + "_DATABASE", Extra: upper + "_",
+		}
+	}
+
+	prefix = envKey(prefix)
+	var vars []EnvVar
+	add := func(name, value string) {
+		if value != "" {
+			vars = append(vars, EnvVar{Name: prefix + name, Value: value})
+		}
+	}
+
+	add(names.URL, ConnectionURI(serviceType, creds))
+	add(names.Host, creds.Host)
+	if creds.Port > 0 {
+		add(names.Port, strconv.Itoa(creds.Port))
+	}
+	add(names.User, creds.Username)
+	add(names.Password, creds.Password)
+	add(names.Database, creds.Database)
+
+	extraKeys := make([]string, 0, len(creds.Extra))
+	for k := range creds.Extra {
+		extraKeys = append(extraKeys, k)
+	}
+	sort.Strings(extraKeys)
+	for _, k := range extraKeys {
+		add(names.Extra+envKey(k), creds.Extra[k])
+	}
+
+	return vars
+}
+
+// ConnectionURI returns the connection URI for a service, building one from
+// the individual credential fields when the API did not return it
+func ConnectionURI(serviceType models.ServiceType, creds *models.ServiceCredentials) string {
+	if creds.URI != "" {
+		return creds.URI
+	}
+	if creds.Host == "" {
+		return ""
+	}
+
+	scheme, ok := uriSchemes[serviceType]
+	if !ok {
+		scheme = string(serviceType)
+	}
+
+	u := url.URL{Scheme: scheme, Host: creds.Host}
+	if creds.Port > 0 {
+		u.Host = fmt.Sprintf("%s:%d", creds.Host, creds.Port)
+	}
+	if creds.Username != "" && creds.Password != "" {
+		u.User = url.UserPassword(creds.Username, creds.Password)
+	} else if creds.Username != "" {
+		u.User = url.User(creds.Username)
+	} else if creds.Password != "" {
+		// Redis AUTH without a username
+		u.User = url.UserPassword("", creds.Password)
+	}
+	if creds.Database != "" {
+		u.Path = "/" + strings.TrimPrefix(creds.Database, "/")
+	}
+
+	return u.String()
+}
+
+// envKey normalizes an arbitrary string into an environment variable name fragment
+func envKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// DotenvQuote quotes a value for a .env file. Single-quoted values are taken
+// literally by dotenv loaders, so a $ in a password is not interpolated. Values
+// containing a single quote or newline are double-quoted with $ escaped.
+func DotenvQuote(s string) string {
+	if !strings.ContainsAny(s, "'\n") {
+		return "'" + s + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// shellQuote quotes a value for safe use in a POSIX shell
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStatusGetter mocks the API client calls a waiter makes
+type mockStatusGetter struct {
+	mock.Mock
+}
+
+func (m *mockStatusGetter) GetService(ctx context.Context, serviceID string) (*models.Service, error) {
+	args := m.Called(ctx, serviceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Service), args.Error(1)
+}
+
+func TestDotenvQuote(t *testing.T) {
+	assert.Equal(t, `'redis.example.com'`, DotenvQuote("redis.example.com"))
+	assert.Equal(t, `'pa$$word'`, DotenvQuote("pa$$word"))
+	assert.Equal(t, `"it's \$HOME \"x\" \\"`, DotenvQuote(`it's $HOME "x" \`))
+	assert.Equal(t, `"a\nb"`, DotenvQuote("a\nb"))
+}
+
+func TestRenderConnection(t *testing.T) {
+	service := &models.Service{
+		Name: "redis-cache",
+		Type: models.ServiceTypeRedis,
+		Credentials: &models.ServiceCredentials{
+			Host:     "redis.example.com",
+			Port:     6379,
+			Password: "s3cr'et",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		prefix   string
+		expected []string
+	}{
+		{
+			name:     "Env format",
+			format:   ConnectFormatEnv,
+			expected: []string{"export REDIS_URL='redis://:s3cr%27et@redis.example.com:6379'", "export REDIS_PASSWORD='s3cr'\\''et'"},
+		},
+		{
+			name:     "Dotenv format with prefix",
+			format:   ConnectFormatDotenv,
+			prefix:   "cache_",
+			expected: []string{`CACHE_REDIS_HOST='redis.example.com'`, `CACHE_REDIS_PORT='6379'`, `CACHE_REDIS_PASSWORD="s3cr'et"`},
+		},
+		{
+			name:     "URI format",
+			format:   ConnectFormatURI,
+			expected: []string{"redis://:s3cr%27et@redis.example.com:6379\n"},
+		},
+		{
+			name:     "JSON format",
+			format:   ConnectFormatJSON,
+			expected: []string{`"REDIS_URL":`, `"host": "redis.example.com"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := RenderConnection(service, tt.format, tt.prefix)
+			require.NoError(t, err)
+			for _, expected := range tt.expected {
+				assert.Contains(t, out, expected)
+			}
+		})
+	}
+}
+
+func TestCredentialEnv(t *testing.T) {
+	creds := &models.ServiceCredentials{
+		Host:     "pg.example.com",
+		Port:     5432,
+		Username: "app",
+		Password: "pw",
+		Database: "myapp",
+		URI:      "postgresql://app:pw@pg.example.com:5432/myapp",
+	}
+
+	vars := CredentialEnv(models.ServiceTypePostgreSQL, creds, "")
+	env := make(map[string]string)
+	for _, v := range vars {
+		env[v.Name] = v.Value
+	}
+
+	assert.Equal(t, creds.URI, env["DATABASE_URL"])
+	assert.Equal(t, "pg.example.com", env["PGHOST"])
+	assert.Equal(t, "5432", env["PGPORT"])
+	assert.Equal(t, "myapp", env["PGDATABASE"])
+}
+
+func TestIsAuthError(t *testing.T) {
+	assert.True(t, IsAuthError(fmt.Errorf("%w: token expired", api.ErrUnauthorized)))
+	assert.True(t, IsAuthError(api.ErrForbidden))
+	assert.False(t, IsAuthError(errors.New("server error: bad gateway")))
+	assert.False(t, IsAuthError(nil))
+}
+
+func TestWait(t *testing.T) {
+	waitInitialInterval, waitMaxInterval = time.Millisecond, time.Millisecond
+	defer func() { waitInitialInterval, waitMaxInterval = time.Second, 15*time.Second }()
+
+	tests := []struct {
+		name    string
+		setup   func(m *mockStatusGetter)
+		cond    Condition
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "Reaches running",
+			setup: func(m *mockStatusGetter) {
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusCreating}, nil).Twice()
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusRunning}, nil).Once()
+			},
+			cond: Condition{Status: models.ServiceStatusRunning},
+		},
+		{
+			name: "Fails",
+			setup: func(m *mockStatusGetter) {
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusFailed}, nil)
+			},
+			cond:    Condition{Status: models.ServiceStatusRunning},
+			wantErr: true,
+		},
+		{
+			name: "Deleted",
+			setup: func(m *mockStatusGetter) {
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusDeleting}, nil).Once()
+				m.On("GetService", mock.Anything, "svc-1").Return(nil, fmt.Errorf("%w: gone", api.ErrNotFound)).Once()
+			},
+			cond:    Condition{Deleted: true},
+			wantNil: true,
+		},
+		{
+			name: "Retries transient errors",
+			setup: func(m *mockStatusGetter) {
+				m.On("GetService", mock.Anything, "svc-1").Return(nil, errors.New("QuickSpin API is experiencing issues. Please try again later")).Twice()
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusRunning}, nil).Once()
+			},
+			cond: Condition{Status: models.ServiceStatusRunning},
+		},
+		{
+			name: "Waits for the scaled tier",
+			setup: func(m *mockStatusGetter) {
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusRunning, Tier: models.ServiceTierStarter}, nil).Once()
+				m.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusRunning, Tier: models.ServiceTierPro, Replicas: 3}, nil).Once()
+			},
+			cond: Condition{Status: models.ServiceStatusRunning, Tier: models.ServiceTierPro, Replicas: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockStatusGetter)
+			tt.setup(client)
+
+			svc, err := Wait(context.Background(), client, "svc-1", tt.cond, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, svc)
+			} else {
+				assert.Equal(t, tt.cond.Status, svc.Status)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWaitStopsOnPermanentErrors(t *testing.T) {
+	waitInitialInterval, waitMaxInterval = time.Millisecond, time.Millisecond
+	defer func() { waitInitialInterval, waitMaxInterval = time.Second, 15*time.Second }()
+
+	for _, err := range []error{fmt.Errorf("%w: token expired", api.ErrUnauthorized), fmt.Errorf("%w: gone", api.ErrNotFound)} {
+		client := new(mockStatusGetter)
+		client.On("GetService", mock.Anything, "svc-1").Return(nil, err)
+
+		_, got := Wait(context.Background(), client, "svc-1", Condition{Status: models.ServiceStatusRunning}, nil)
+		assert.ErrorIs(t, got, err)
+		client.AssertNumberOfCalls(t, "GetService", 1)
+	}
+}
+
+func TestScaleCondition(t *testing.T) {
+	tier, replicas := models.ServiceTierPro, 3
+	cond := ScaleCondition(models.ServiceScaleRequest{Tier: &tier, Replicas: &replicas})
+	assert.Equal(t, "become running on pro tier with 3 replica(s)", cond.String())
+	assert.False(t, cond.Met(&models.Service{Status: models.ServiceStatusRunning, Tier: models.ServiceTierStarter, Replicas: 3}))
+	assert.True(t, cond.Met(&models.Service{Status: models.ServiceStatusRunning, Tier: models.ServiceTierPro, Replicas: 3}))
+}
+
+func TestWaitTimeout(t *testing.T) {
+	waitInitialInterval, waitMaxInterval = time.Millisecond, time.Millisecond
+	defer func() { waitInitialInterval, waitMaxInterval = time.Second, 15*time.Second }()
+
+	client := new(mockStatusGetter)
+	client.On("GetService", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Status: models.ServiceStatusCreating}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := Wait(ctx, client, "svc-1", Condition{Status: models.ServiceStatusRunning}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Contains(t, err.Error(), "creating")
+}
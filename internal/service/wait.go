@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quickspin/quickspin-cli/internal/api"
+	"github.com/quickspin/quickspin-cli/internal/models"
+)
+
+var (
+	// waitInitialInterval is the first polling interval
+	waitInitialInterval = time.Second
+	// waitMaxInterval caps the exponential backoff
+	waitMaxInterval = 15 * time.Second
+)
+
+// Condition is what a waiter waits for
+type Condition struct {
+	Status  models.ServiceStatus
+	Deleted bool
+	// Tier and Replicas, when set, must also be reported by the service, so
+	// a wait after scaling does not end before the scale has started
+	Tier     models.ServiceTier
+	Replicas int
+}
+
+// String describes the condition for progress messages
+func (c Condition) String() string {
+	if c.Deleted {
+		return "be deleted"
+	}
+	if c.Tier != "" || c.Replicas > 0 {
+		var req models.ServiceScaleRequest
+		if c.Tier != "" {
+			req.Tier = &c.Tier
+		}
+		if c.Replicas > 0 {
+			req.Replicas = &c.Replicas
+		}
+		return fmt.Sprintf("become %s on %s", c.Status, DescribeScale(req))
+	}
+	return fmt.Sprintf("become %s", c.Status)
+}
+
+// Met reports whether a service satisfies a status condition
+func (c Condition) Met(svc *models.Service) bool {
+	if c.Deleted || svc.Status != c.Status {
+		return false
+	}
+	if c.Tier != "" && svc.Tier != c.Tier {
+		return false
+	}
+	return c.Replicas == 0 || svc.ReplicaCount() == c.Replicas
+}
+
+// ScaleCondition is the condition of a service having finished a scale request
+func ScaleCondition(req models.ServiceScaleRequest) Condition {
+	cond := Condition{Status: models.ServiceStatusRunning}
+	if req.Tier != nil {
+		cond.Tier = *req.Tier
+	}
+	if req.Replicas != nil {
+		cond.Replicas = *req.Replicas
+	}
+	return cond
+}
+
+// DescribeScale summarizes a scale request for messages
+func DescribeScale(req models.ServiceScaleRequest) string {
+	var parts []string
+	if req.Tier != nil {
+		parts = append(parts, fmt.Sprintf("%s tier", *req.Tier))
+	}
+	if req.Replicas != nil {
+		parts = append(parts, fmt.Sprintf("%d replica(s)", *req.Replicas))
+	}
+	return strings.Join(parts, " with ")
+}
+
+// IsAuthError reports whether an API error needs the user to log in again or
+// lacks permission, so retrying cannot succeed
+func IsAuthError(err error) bool {
+	return errors.Is(err, api.ErrUnauthorized) || errors.Is(err, api.ErrForbidden)
+}
+
+// StatusGetter is the subset of the API client needed to poll a service
+type StatusGetter interface {
+	GetService(ctx context.Context, serviceID string) (*models.Service, error)
+}
+
+// Wait polls a service with exponential backoff until it satisfies the
+// condition, fails, or the context expires. onPoll, if set, is called after
+// each successful poll. It returns the last observed service (nil once
+// deleted). Transient errors such as timeouts and 5xx responses are retried;
+// only a missing service and authentication errors end the wait early.
+func Wait(ctx context.Context, client StatusGetter, serviceID string, cond Condition, onPoll func(*models.Service)) (*models.Service, error) {
+	interval := waitInitialInterval
+	var last *models.Service
+	var lastErr error
+
+	for {
+		svc, err := client.GetService(ctx, serviceID)
+		switch {
+		case err == nil:
+			last, lastErr = svc, nil
+			if onPoll != nil {
+				onPoll(svc)
+			}
+			if cond.Met(svc) {
+				return svc, nil
+			}
+			if svc.Status == models.ServiceStatusFailed {
+				return svc, fmt.Errorf("service '%s' entered the failed state", svc.Name)
+			}
+		case errors.Is(err, api.ErrNotFound):
+			if cond.Deleted {
+				return nil, nil
+			}
+			return last, err
+		case IsAuthError(err):
+			return last, err
+		case ctx.Err() != nil:
+			// Reported below
+		default:
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			current := "unknown"
+			if last != nil {
+				current = string(last.Status)
+			}
+			if lastErr != nil {
+				current += fmt.Sprintf(", last error: %s", lastErr)
+			}
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return last, fmt.Errorf("timed out waiting for service to %s (current status: %s)", cond, current)
+			}
+			return last, fmt.Errorf("stopped waiting for service to %s (current status: %s)", cond, current)
+		case <-time.After(interval):
+		}
+
+		interval = interval * 3 / 2
+		if interval > waitMaxInterval {
+			interval = waitMaxInterval
+		}
+	}
+}
+
+// WaitForRunning polls a service until it is running, fails, or the context
+// expires. onPoll, if set, is called with each observed state.
+func WaitForRunning(ctx context.Context, client StatusGetter, serviceID string, onPoll func(*models.Service)) (*models.Service, error) {
+	return Wait(ctx, client, serviceID, Condition{Status: models.ServiceStatusRunning}, onPoll)
+}
+
+// WaitForScale polls a service until it is running on the tier and replica
+// count of a scale request, fails, or the context expires. onPoll, if set, is
+// called with each observed state.
+func WaitForScale(ctx context.Context, client StatusGetter, serviceID string, req models.ServiceScaleRequest, onPoll func(*models.Service)) (*models.Service, error) {
+	return Wait(ctx, client, serviceID, ScaleCondition(req), onPoll)
+}
@@ -132,6 +132,7 @@ type MultiStepProgress struct {
 	totalSteps  int
 	stepLabels  []string
 	width       int
+	failed      bool
 }
 
 // NewMultiStepProgress creates a multi-step progress indicator
@@ -205,6 +206,37 @@ func (msp MultiStepProgress) View() string {
 	)
 }
 
+// Fail marks the current step as failed
+func (msp *MultiStepProgress) Fail() {
+	msp.failed = true
+}
+
+// IsFailed returns true if the current step failed
+func (msp MultiStepProgress) IsFailed() bool {
+	return msp.failed
+}
+
+// InlineView renders the steps on a single line, marking the last step done
+// once it is reached
+func (msp MultiStepProgress) InlineView() string {
+	theme := styles.GetTheme()
+
+	var steps []string
+	for i, label := range msp.stepLabels {
+		switch {
+		case i < msp.currentStep || (i == msp.currentStep && msp.IsComplete() && !msp.failed):
+			steps = append(steps, lipgloss.NewStyle().Foreground(theme.Success).Render("✓ "+label))
+		case i == msp.currentStep && msp.failed:
+			steps = append(steps, lipgloss.NewStyle().Foreground(theme.Error).Bold(true).Render("✗ "+label))
+		case i == msp.currentStep:
+			steps = append(steps, lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render("▶ "+label))
+		default:
+			steps = append(steps, lipgloss.NewStyle().Foreground(theme.Muted).Render("  "+label))
+		}
+	}
+	return strings.Join(steps, "  ")
+}
+
 // GetCurrentStep returns the current step index
 func (msp MultiStepProgress) GetCurrentStep() int {
 	return msp.currentStep